@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"fmt"
 	"log"
 	"net/http"
 	"os"
@@ -9,18 +10,168 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/Cohen-J-Omer/k8-task-mgmt-system/taskmgmt/internal/auth"
 	"github.com/Cohen-J-Omer/k8-task-mgmt-system/taskmgmt/internal/config"
 	"github.com/Cohen-J-Omer/k8-task-mgmt-system/taskmgmt/internal/handler"
+	"github.com/Cohen-J-Omer/k8-task-mgmt-system/taskmgmt/internal/identity"
+	"github.com/Cohen-J-Omer/k8-task-mgmt-system/taskmgmt/internal/logging"
+	"github.com/Cohen-J-Omer/k8-task-mgmt-system/taskmgmt/internal/metrics"
 	"github.com/Cohen-J-Omer/k8-task-mgmt-system/taskmgmt/internal/middleware"
+	"github.com/Cohen-J-Omer/k8-task-mgmt-system/taskmgmt/internal/mtls"
+	"github.com/Cohen-J-Omer/k8-task-mgmt-system/taskmgmt/internal/ratelimit"
+	"github.com/Cohen-J-Omer/k8-task-mgmt-system/taskmgmt/internal/rbac"
+	"github.com/Cohen-J-Omer/k8-task-mgmt-system/taskmgmt/internal/resilience"
+	"github.com/Cohen-J-Omer/k8-task-mgmt-system/taskmgmt/internal/svcauth"
+	"github.com/Cohen-J-Omer/k8-task-mgmt-system/taskmgmt/internal/tracing"
+	"github.com/Cohen-J-Omer/k8-task-mgmt-system/taskmgmt/internal/validator"
 	pb "github.com/Cohen-J-Omer/k8-task-mgmt-system/taskmgmt/proto"
 	"github.com/gin-gonic/gin"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
+	_ "google.golang.org/grpc/health" // enables the client side health checks of the service config
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/keepalive"
 )
 
+// defaultAttachmentTypes covers logs and screenshots, override with ATTACHMENT_ALLOWED_TYPES
+var defaultAttachmentTypes = []string{"text/plain", "image/*", "application/pdf", "application/json", "application/zip", "application/x-gzip"}
+
+// newAuthenticator chains the authenticators enabled by AUTH_MODES, a comma separated list of
+// apikey (the default) and jwt. BEARER_TOKEN always remains usable as the bootstrap admin key.
+func newAuthenticator(bearerToken string, authClient pb.AuthServiceClient) (auth.Authenticator, error) {
+	authenticator := auth.Chain{auth.NewStaticToken(bearerToken)}
+	for _, mode := range config.GetEnvList("AUTH_MODES", []string{"apikey"}) {
+		switch mode {
+		case "apikey":
+			ttl := time.Duration(config.GetEnvInt64("API_KEY_CACHE_SECONDS", 30)) * time.Second
+			authenticator = append(authenticator, auth.NewAPIKeys(authClient, ttl))
+		case "jwt":
+			source, ok := os.LookupEnv("JWT_JWKS")
+			if !ok || source == "" {
+				return nil, fmt.Errorf("environment variable JWT_JWKS must be set to a JWKS file or URL for jwt authentication")
+			}
+			refresh := time.Duration(config.GetEnvInt64("JWT_JWKS_REFRESH_SECONDS", 300)) * time.Second
+			keys, err := auth.NewJWKS(context.Background(), source, refresh)
+			if err != nil {
+				return nil, err
+			}
+			authenticator = append(authenticator, auth.NewJWT(keys, auth.JWTConfig{
+				Issuer:         config.GetEnv("JWT_ISSUER", ""),
+				Audience:       config.GetEnv("JWT_AUDIENCE", ""),
+				ClockSkew:      time.Duration(config.GetEnvInt64("JWT_CLOCK_SKEW_SECONDS", 60)) * time.Second,
+				UserClaim:      config.GetEnv("JWT_USER_CLAIM", "sub"),
+				RolesClaim:     config.GetEnv("JWT_ROLES_CLAIM", "roles"),
+				TeamsClaim:     config.GetEnv("JWT_TEAMS_CLAIM", "groups"),
+				WorkspaceClaim: config.GetEnv("JWT_WORKSPACE_CLAIM", "workspace"),
+				// opt-in, as tokens without the claim are otherwise rejected
+				DefaultWorkspace: config.GetEnv("JWT_DEFAULT_WORKSPACE", ""),
+			}))
+		default:
+			return nil, fmt.Errorf("unknown AUTH_MODES entry %q, expected apikey or jwt", mode)
+		}
+	}
+	return authenticator, nil
+}
+
+// backendCredentials returns the credentials to dial the backend with: mutual TLS when BACKEND_TLS_CERT,
+// BACKEND_TLS_KEY and BACKEND_TLS_CA name the API's certificate, its key and the CA of the backend's
+// certificate, and plaintext otherwise. The files are reloaded when they change.
+func backendCredentials() (credentials.TransportCredentials, error) {
+	cfg := mtls.Config{
+		CertFile:       config.GetEnv("BACKEND_TLS_CERT", ""),
+		KeyFile:        config.GetEnv("BACKEND_TLS_KEY", ""),
+		CAFile:         config.GetEnv("BACKEND_TLS_CA", ""),
+		ReloadInterval: time.Duration(config.GetEnvInt64("TLS_RELOAD_SECONDS", 30)) * time.Second,
+	}
+	if !cfg.Enabled() {
+		return insecure.NewCredentials(), nil
+	}
+	certs, err := mtls.NewReloader(cfg)
+	if err != nil {
+		return nil, err
+	}
+	// the backend's certificate must be valid for BACKEND_TLS_SERVER_NAME, by default the host of BACKEND_GRPC_ADDR
+	return credentials.NewTLS(certs.ClientConfig(config.GetEnv("BACKEND_TLS_SERVER_NAME", ""))), nil
+}
+
+// backendServiceConfig balances calls across all the backend's addresses, skipping those whose health
+// service isn't SERVING, such as draining replicas. Resolving a headless service, e.g.
+// dns:///backend-headless:50051, gives one address per replica.
+const backendServiceConfig = `{
+	"loadBalancingConfig": [{"round_robin": {}}],
+	"healthCheckConfig": {"serviceName": ""}
+}`
+
+// backendBalancing returns the dial options spreading calls across the backend's replicas, using the
+// gRPC service config in BACKEND_SERVICE_CONFIG or else backendServiceConfig, and keeping idle
+// connections alive every BACKEND_KEEPALIVE_SECONDS so dead replicas are noticed.
+func backendBalancing() []grpc.DialOption {
+	return []grpc.DialOption{
+		grpc.WithDefaultServiceConfig(config.GetEnv("BACKEND_SERVICE_CONFIG", backendServiceConfig)),
+		grpc.WithKeepaliveParams(keepalive.ClientParameters{
+			Time:                time.Duration(config.GetEnvInt64("BACKEND_KEEPALIVE_SECONDS", 30)) * time.Second,
+			Timeout:             10 * time.Second,
+			PermitWithoutStream: true,
+		}),
+	}
+}
+
+// defaultRouteTimeouts are the routes whose requests need another timeout than REQUEST_TIMEOUT_SECONDS.
+// Time reports aggregate all the worklogs of their range, and attachments stream for as long as their
+// size requires.
+var defaultRouteTimeouts = map[string]time.Duration{
+	"GET /tasks":                                5 * time.Second,
+	"GET /reports/time":                         30 * time.Second,
+	"POST /tasks/:id/attachments":               0,
+	"GET /tasks/:id/attachments/:attachment_id": 0,
+}
+
+// newRateLimiter returns the rate limiting policy configured by RATE_LIMIT, the default "rate:burst"
+// limit, and RATE_LIMIT_ROLES, RATE_LIMIT_USERS and RATE_LIMIT_KEYS, comma separated "name=rate:burst"
+// limits of roles, user IDs and API key IDs, the limit of each client IP configured by RATE_LIMIT_IP, along with the store keeping
+// the buckets: the API replica's memory, or the backend when RATE_LIMIT_STORE is "backend".
+func newRateLimiter(client pb.RateLimitServiceClient) (ratelimit.Policy, ratelimit.Limit, ratelimit.Store, error) {
+	var policy ratelimit.Policy
+	var err error
+	if policy.Default, err = ratelimit.ParseLimit(config.GetEnv("RATE_LIMIT", "20:40")); err != nil {
+		return policy, ratelimit.Limit{}, nil, err
+	}
+	// generous, as clients may share an IP, e.g. behind NAT
+	ipLimit, err := ratelimit.ParseLimit(config.GetEnv("RATE_LIMIT_IP", "100:200"))
+	if err != nil {
+		return policy, ipLimit, nil, err
+	}
+	if policy.Roles, err = ratelimit.ParseLimits(config.GetEnvList("RATE_LIMIT_ROLES", nil)); err != nil {
+		return policy, ipLimit, nil, err
+	}
+	if policy.Users, err = ratelimit.ParseLimits(config.GetEnvList("RATE_LIMIT_USERS", nil)); err != nil {
+		return policy, ipLimit, nil, err
+	}
+	if policy.Keys, err = ratelimit.ParseLimits(config.GetEnvList("RATE_LIMIT_KEYS", nil)); err != nil {
+		return policy, ipLimit, nil, err
+	}
+	switch store := config.GetEnv("RATE_LIMIT_STORE", "local"); store {
+	case "local":
+		return policy, ipLimit, ratelimit.NewLocalStore(), nil
+	case "backend":
+		return policy, ipLimit, ratelimit.NewBackendStore(client), nil
+	default:
+		return policy, ipLimit, nil, fmt.Errorf("unknown RATE_LIMIT_STORE %q, expected local or backend", store)
+	}
+}
+
 func main() {
 	// loads .env for local debugging
 	config.LoadDotenvIfDebug()
+	// log JSON lines, the standard logger included
+	if err := logging.Setup("task-api"); err != nil {
+		log.Fatal(err)
+	}
+	// LOG_LEVEL_FILE, such as a mounted ConfigMap key, sets the level of every replica at runtime
+	if path := config.GetEnv("LOG_LEVEL_FILE", ""); path != "" {
+		go logging.WatchLevelFile(context.Background(), path, time.Duration(config.GetEnvInt64("LOG_LEVEL_FILE_SECONDS", 10))*time.Second)
+	}
 
 	grpcAddr, ok := os.LookupEnv("BACKEND_GRPC_ADDR")
 	if !ok || grpcAddr == "" {
@@ -31,32 +182,178 @@ func main() {
 	if !ok2 || bearerToken == "" {
 		log.Fatal("Environment variable BEARER_TOKEN is not set")
 	}
+	// shared with the backend, which only accepts calls signed with it
+	serviceSecret, ok3 := os.LookupEnv("SERVICE_SECRET")
+	if !ok3 || serviceSecret == "" {
+		log.Fatal("Environment variable SERVICE_SECRET is not set")
+	}
 	// Create a gRPC client connection
+	shutdownTracing, err := tracing.Setup(context.Background(), "task-api")
+	if err != nil {
+		log.Fatal(err)
+	}
 	log.Printf("Connecting to gRPC server at %s", grpcAddr)
-	conn, err := grpc.NewClient(grpcAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	creds, err := backendCredentials()
+	if err != nil {
+		log.Fatal(err)
+	}
+	// retry policies per method, and a circuit breaker failing calls fast while the backend is down
+	retries, err := resilience.ParseConfig(config.GetEnv("BACKEND_RETRY_POLICIES", ""))
+	if err != nil {
+		log.Fatal(err)
+	}
+	breaker := resilience.NewBreaker(int(config.GetEnvInt64("BREAKER_FAILURES", 5)),
+		time.Duration(config.GetEnvInt64("BREAKER_COOLDOWN_SECONDS", 10))*time.Second)
+	conn, err := grpc.NewClient(grpcAddr, append(backendBalancing(),
+		grpc.WithTransportCredentials(creds),
+		// continue the request's trace in the backend
+		grpc.WithStatsHandler(tracing.ClientHandler()),
+		// retries come first, so each attempt carries the request ID and caller's identity, signed anew
+		grpc.WithChainUnaryInterceptor(resilience.UnaryClientInterceptor(retries, breaker), logging.UnaryClientInterceptor(), identity.UnaryClientInterceptor(), svcauth.UnaryClientInterceptor(serviceSecret)),
+		grpc.WithChainStreamInterceptor(resilience.StreamClientInterceptor(breaker), logging.StreamClientInterceptor(), identity.StreamClientInterceptor(), svcauth.StreamClientInterceptor(serviceSecret)),
+	)...)
 	if err != nil {
 		log.Fatal(err)
 	}
 	defer conn.Close()
 
-	// Create a new gRPC client
+	// Create new gRPC clients sharing the connection
 	client := pb.NewTaskServiceClient(conn)
+	templateClient := pb.NewTemplateServiceClient(conn)
+	userClient := pb.NewUserServiceClient(conn)
+	authClient := pb.NewAuthServiceClient(conn)
+	workspaceClient := pb.NewWorkspaceServiceClient(conn)
+	projectClient := pb.NewProjectServiceClient(conn)
+	adminClient := pb.NewAdminServiceClient(conn)
+	milestoneClient := pb.NewMilestoneServiceClient(conn)
 
 	// Set up Gin router
-	r := gin.Default()
-	
-	taskHandler := handler.NewTaskHandler(client)
-	// add a health readiness/liveness entry point for k8
-	// This allows Kubernetes HPA to check the health of the API server.
-	r.GET("/health", func(c *gin.Context) { c.JSON(http.StatusOK, gin.H{"status": "ok"}) })
-	// /health is always accessible not requiring authentication token
-	r.Use(middleware.AuthMiddleware(bearerToken))
-	r.POST("/tasks", taskHandler.CreateTask)
-	r.GET("/tasks", taskHandler.GetTasks)
-	r.GET("/tasks/:id", taskHandler.GetTask)
-	r.PUT("/tasks/:id", taskHandler.UpdateTask)
-	r.DELETE("/tasks/:id", taskHandler.DeleteTask)
-	
+	// gin.Default's text logger is replaced by JSON request logs carrying request IDs,
+	// and its debug output is off unless GIN_MODE asks for it
+	if _, ok := os.LookupEnv(gin.EnvGinMode); !ok {
+		gin.SetMode(gin.ReleaseMode)
+	}
+	r := gin.New()
+	// client IPs, which requests are rate limited by, are only taken from X-Forwarded-For when set by a
+	// trusted proxy, e.g. an ingress controller, so clients can't spoof them
+	if err := r.SetTrustedProxies(config.GetEnvList("TRUSTED_PROXIES", nil)); err != nil {
+		log.Fatal(err)
+	}
+	// add liveness and readiness entry points for k8, readiness following the backend's health.
+	// Probes are registered first, so they aren't logged, traced or counted.
+	// /health is the liveness check's former name.
+	healthHandler := handler.NewHealthHandler(healthpb.NewHealthClient(conn),
+		time.Duration(config.GetEnvInt64("READINESS_TIMEOUT_SECONDS", 2))*time.Second)
+	r.GET("/livez", healthHandler.Livez)
+	r.GET("/health", healthHandler.Livez)
+	r.GET("/readyz", healthHandler.Readyz)
+	r.Use(logging.GinMiddleware(), logging.Recovery(), tracing.GinMiddleware("task-api"), metrics.HTTPMiddleware())
+
+	taskHandler := handler.NewTaskHandler(client, validator.AttachmentLimits{
+		MaxBytes:     config.GetEnvInt64("ATTACHMENT_MAX_BYTES", 10<<20),
+		AllowedTypes: config.GetEnvList("ATTACHMENT_ALLOWED_TYPES", defaultAttachmentTypes),
+	})
+	templateHandler := handler.NewTemplateHandler(templateClient)
+	userHandler := handler.NewUserHandler(userClient)
+	apiKeyHandler := handler.NewAPIKeyHandler(authClient)
+	workspaceHandler := handler.NewWorkspaceHandler(workspaceClient)
+	projectHandler := handler.NewProjectHandler(projectClient)
+	adminHandler := handler.NewAdminHandler(adminClient)
+	milestoneHandler := handler.NewMilestoneHandler(milestoneClient)
+	authenticator, err := newAuthenticator(bearerToken, authClient)
+	if err != nil {
+		log.Fatal(err)
+	}
+	// every request gets a deadline, passed on to the backend, which authenticating API keys is subject to too
+	routeTimeouts, err := middleware.ParseRouteTimeouts(config.GetEnvList("ROUTE_TIMEOUTS", nil), defaultRouteTimeouts)
+	if err != nil {
+		log.Fatal(err)
+	}
+	r.Use(middleware.Deadline(middleware.Timeouts{
+		Default: time.Duration(config.GetEnvInt64("REQUEST_TIMEOUT_SECONDS", 2)) * time.Second,
+		Routes:  routeTimeouts,
+		Max:     time.Duration(config.GetEnvInt64("REQUEST_TIMEOUT_MAX_SECONDS", 30)) * time.Second,
+	}))
+	// each client IP's requests are rate limited before authentication, failing ones included,
+	// then each caller's
+	rateLimitPolicy, ipLimit, rateLimitStore, err := newRateLimiter(pb.NewRateLimitServiceClient(conn))
+	if err != nil {
+		log.Fatal(err)
+	}
+	r.Use(middleware.RateLimitIP(ipLimit, rateLimitStore))
+	// probes are always accessible not requiring authentication token
+	r.Use(middleware.AuthMiddleware(authenticator, auth.NewUsers(authClient)))
+	r.Use(middleware.RateLimit(rateLimitPolicy, rateLimitStore))
+	// every route requires a permission granted by the caller's roles, the backend enforcing the same policy
+	can := middleware.RequirePermission
+	r.POST("/tasks", can(rbac.TaskCreate), taskHandler.CreateTask)
+	r.GET("/tasks", can(rbac.TaskList), taskHandler.GetTasks)
+	r.GET("/tasks/:id", can(rbac.TaskGet), taskHandler.GetTask)
+	r.PUT("/tasks/:id", can(rbac.TaskUpdate), taskHandler.UpdateTask)
+	r.DELETE("/tasks/:id", can(rbac.TaskDelete), taskHandler.DeleteTask)
+	r.POST("/tasks/:id/attachments", can(rbac.TaskUpdate), taskHandler.UploadAttachment)
+	r.GET("/tasks/:id/attachments/:attachment_id", can(rbac.TaskGet), taskHandler.DownloadAttachment)
+	r.DELETE("/tasks/:id/attachments/:attachment_id", can(rbac.TaskUpdate), taskHandler.DeleteAttachment)
+	r.POST("/tasks/:id/checklist", can(rbac.TaskUpdate), taskHandler.AddChecklistItem)
+	r.PUT("/tasks/:id/checklist/order", can(rbac.TaskUpdate), taskHandler.ReorderChecklist)
+	r.POST("/tasks/:id/checklist/:item_id/toggle", can(rbac.TaskUpdate), taskHandler.ToggleChecklistItem)
+	r.DELETE("/tasks/:id/checklist/:item_id", can(rbac.TaskUpdate), taskHandler.RemoveChecklistItem)
+	r.GET("/tasks/:id/occurrences", can(rbac.TaskGet), taskHandler.ListOccurrences)
+	r.POST("/tasks/:id/timer/start", can(rbac.TaskUpdate), taskHandler.StartTimer)
+	r.POST("/tasks/:id/timer/stop", can(rbac.TaskUpdate), taskHandler.StopTimer)
+	r.POST("/tasks/:id/worklogs", can(rbac.TaskUpdate), taskHandler.AddWorklog)
+	r.DELETE("/tasks/:id/worklogs/:worklog_id", can(rbac.TaskUpdate), taskHandler.DeleteWorklog)
+	r.POST("/tasks/:id/assignees", can(rbac.TaskUpdate), taskHandler.AddAssignee)
+	r.DELETE("/tasks/:id/assignees/:user_id", can(rbac.TaskUpdate), taskHandler.RemoveAssignee)
+	r.POST("/tasks/:id/watchers", can(rbac.TaskUpdate), taskHandler.AddWatcher)
+	r.DELETE("/tasks/:id/watchers/:user_id", can(rbac.TaskUpdate), taskHandler.RemoveWatcher)
+	r.PUT("/tasks/:id/acl", can(rbac.TaskUpdate), taskHandler.SetTaskACL)
+	r.GET("/tasks/:id/access", can(rbac.TaskAudit), taskHandler.GetTaskAccess)
+	r.GET("/reports/time", can(rbac.TaskList), taskHandler.GetTimeReport)
+	r.POST("/templates", can(rbac.TemplateWrite), templateHandler.CreateTemplate)
+	r.GET("/templates", can(rbac.TemplateRead), templateHandler.GetTemplates)
+	r.GET("/templates/:name", can(rbac.TemplateRead), templateHandler.GetTemplate)
+	r.PUT("/templates/:name", can(rbac.TemplateWrite), templateHandler.UpdateTemplate)
+	r.DELETE("/templates/:name", can(rbac.TemplateWrite), templateHandler.DeleteTemplate)
+	r.POST("/templates/:name/instantiate", can(rbac.TaskCreate), templateHandler.InstantiateTemplate)
+	r.POST("/projects", can(rbac.ProjectWrite), projectHandler.CreateProject)
+	r.GET("/projects", can(rbac.ProjectRead), projectHandler.GetProjects)
+	r.GET("/projects/:id", can(rbac.ProjectRead), projectHandler.GetProject)
+	r.PUT("/projects/:id", can(rbac.ProjectWrite), projectHandler.UpdateProject)
+	r.DELETE("/projects/:id", can(rbac.ProjectWrite), projectHandler.DeleteProject)
+	r.POST("/boards", can(rbac.ProjectWrite), projectHandler.CreateBoard)
+	r.GET("/boards", can(rbac.ProjectRead), projectHandler.GetBoards)
+	r.GET("/boards/:id", can(rbac.ProjectRead), projectHandler.GetBoard)
+	r.PUT("/boards/:id", can(rbac.ProjectWrite), projectHandler.UpdateBoard)
+	r.DELETE("/boards/:id", can(rbac.ProjectWrite), projectHandler.DeleteBoard)
+	r.POST("/boards/:id/cards/:task_id/move", can(rbac.TaskUpdate), projectHandler.MoveCard)
+	r.POST("/milestones", can(rbac.ProjectWrite), milestoneHandler.CreateMilestone)
+	r.GET("/milestones", can(rbac.ProjectRead), milestoneHandler.GetMilestones)
+	r.GET("/milestones/:id", can(rbac.ProjectRead), milestoneHandler.GetMilestone)
+	r.PUT("/milestones/:id", can(rbac.ProjectWrite), milestoneHandler.UpdateMilestone)
+	r.DELETE("/milestones/:id", can(rbac.ProjectWrite), milestoneHandler.DeleteMilestone)
+	r.GET("/milestones/:id/burndown", can(rbac.ProjectRead), milestoneHandler.GetBurndown)
+	r.POST("/users", can(rbac.UserManage), userHandler.CreateUser)
+	r.GET("/users", can(rbac.UserRead), userHandler.GetUsers)
+	r.GET("/users/:id", can(rbac.UserRead), userHandler.GetUser)
+	r.PUT("/users/:id", can(rbac.UserManage), userHandler.UpdateUser)
+	r.POST("/teams", can(rbac.UserManage), userHandler.CreateTeam)
+	r.GET("/teams", can(rbac.UserRead), userHandler.GetTeams)
+	r.GET("/teams/:id", can(rbac.UserRead), userHandler.GetTeam)
+	r.POST("/teams/:id/members", can(rbac.UserManage), userHandler.AddTeamMember)
+	r.DELETE("/teams/:id/members/:user_id", can(rbac.UserManage), userHandler.RemoveTeamMember)
+	r.POST("/admin/keys", can(rbac.APIKeyManage), apiKeyHandler.CreateKey)
+	r.GET("/admin/keys", can(rbac.APIKeyManage), apiKeyHandler.GetKeys)
+	r.DELETE("/admin/keys/:id", can(rbac.APIKeyManage), apiKeyHandler.RevokeKey)
+	r.GET("/admin/log-level", can(rbac.SystemManage), adminHandler.GetLogLevel)
+	r.PUT("/admin/log-level", can(rbac.SystemManage), adminHandler.SetLogLevel)
+	// workspaces are further limited to operators by the backend
+	r.POST("/workspaces", can(rbac.WorkspaceManage), workspaceHandler.CreateWorkspace)
+	r.GET("/workspaces", can(rbac.WorkspaceManage), workspaceHandler.GetWorkspaces)
+	r.GET("/workspaces/:id", can(rbac.WorkspaceManage), workspaceHandler.GetWorkspace)
+	r.PUT("/workspaces/:id", can(rbac.WorkspaceManage), workspaceHandler.UpdateWorkspace)
+	r.DELETE("/workspaces/:id", can(rbac.WorkspaceManage), workspaceHandler.DeleteWorkspace)
+
 	srv := &http.Server{
 		Addr:    ":8080",
 		Handler: r,
@@ -68,18 +365,43 @@ func main() {
 		}
 	}()
 	log.Println("REST API server started on :8080")
-		
+
+	// serve Prometheus metrics on their own port, kept off the public API port
+	metricsMux := http.NewServeMux()
+	metricsMux.Handle("/metrics", metrics.Handler())
+	metricsServer := &http.Server{Addr: ":" + config.GetEnv("METRICS_PORT", "9090"), Handler: metricsMux}
+	go func() {
+		if err := metricsServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			log.Fatalf("failed to serve metrics: %v", err)
+		}
+	}()
+
 	// Wait for interrupt signal to gracefully shutdown the server
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
 	log.Println("Shutting down REST API server...")
-	
-	// gives the API server up to 10 seconds to finish handling any in-flight requests and shut down gracefully
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+
+	// fail readiness first and keep serving for DRAIN_SECONDS, until Kubernetes stops routing requests here
+	healthHandler.Drain()
+	time.Sleep(time.Duration(config.GetEnvInt64("DRAIN_SECONDS", 5)) * time.Second)
+
+	// gives the API server up to SHUTDOWN_TIMEOUT_SECONDS to finish handling any in-flight requests and shut down gracefully
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(config.GetEnvInt64("SHUTDOWN_TIMEOUT_SECONDS", 10))*time.Second)
 	defer cancel()
 	if err := srv.Shutdown(ctx); err != nil {
-		log.Fatal("REST API forced to shutdown:", err)
+		log.Printf("REST API forced to shutdown: %v", err)
+		srv.Close()
+	} else {
+		log.Println("REST API server exited gracefully")
+	}
+	metricsServer.Close()
+	if err := conn.Close(); err != nil {
+		log.Printf("failed to close the backend connection: %v", err)
+	}
+	flushCtx, cancelFlush := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancelFlush()
+	if err := shutdownTracing(flushCtx); err != nil {
+		log.Printf("failed to flush traces: %v", err)
 	}
-	log.Println("REST API server exited gracefully")
 }
@@ -0,0 +1,136 @@
+package main
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/Cohen-J-Omer/k8-task-mgmt-system/taskmgmt/internal/resilience"
+	pb "github.com/Cohen-J-Omer/k8-task-mgmt-system/taskmgmt/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/resolver"
+	"google.golang.org/grpc/resolver/manual"
+)
+
+// replica is a backend replica answering GetTask with its name.
+type replica struct {
+	pb.UnimplementedTaskServiceServer
+	name   string
+	addr   string
+	server *grpc.Server
+	health *health.Server
+}
+
+func (r *replica) GetTask(context.Context, *pb.TaskID) (*pb.Task, error) {
+	return &pb.Task{Id: r.name}, nil
+}
+
+// startReplicas starts n replicas listening on loopback addresses.
+func startReplicas(t *testing.T, n int) []*replica {
+	t.Helper()
+	replicas := make([]*replica, n)
+	for i := range replicas {
+		ln, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			t.Fatalf("failed to listen: %v", err)
+		}
+		r := &replica{name: string(rune('a' + i)), addr: ln.Addr().String(), server: grpc.NewServer(), health: health.NewServer()}
+		pb.RegisterTaskServiceServer(r.server, r)
+		healthpb.RegisterHealthServer(r.server, r.health)
+		go func() { _ = r.server.Serve(ln) }()
+		t.Cleanup(r.server.Stop)
+		replicas[i] = r
+	}
+	return replicas
+}
+
+// dialReplicas connects to the replicas as the API connects to the backend's headless service, retrying
+// reads by default, so a call racing a replica's shutdown is retried on another one.
+func dialReplicas(t *testing.T, replicas []*replica) pb.TaskServiceClient {
+	t.Helper()
+	res := manual.NewBuilderWithScheme("test")
+	var addrs []resolver.Address
+	for _, r := range replicas {
+		addrs = append(addrs, resolver.Address{Addr: r.addr})
+	}
+	res.InitialState(resolver.State{Addresses: addrs})
+	conn, err := grpc.NewClient(res.Scheme()+":///backend", append(backendBalancing(),
+		grpc.WithResolvers(res),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithUnaryInterceptor(resilience.UnaryClientInterceptor(resilience.DefaultConfig, resilience.NewBreaker(5, time.Second))),
+	)...)
+	if err != nil {
+		t.Fatalf("failed to dial: %v", err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+	return pb.NewTaskServiceClient(conn)
+}
+
+// callsPerReplica makes n calls, counting those each replica answered.
+func callsPerReplica(t *testing.T, client pb.TaskServiceClient, n int) map[string]int {
+	t.Helper()
+	counts := make(map[string]int)
+	for i := 0; i < n; i++ {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		task, err := client.GetTask(ctx, &pb.TaskID{Id: "t1"}, grpc.WaitForReady(true))
+		cancel()
+		if err != nil {
+			t.Fatalf("call %d failed: %v", i, err)
+		}
+		counts[task.Id]++
+	}
+	return counts
+}
+
+// awaitCalls makes calls until they're spread over exactly the wanted replicas, or fails the test.
+func awaitCalls(t *testing.T, client pb.TaskServiceClient, want ...string) {
+	t.Helper()
+	deadline := time.Now().Add(10 * time.Second)
+	for {
+		counts := callsPerReplica(t, client, 3*len(want))
+		ok := len(counts) == len(want)
+		for _, name := range want {
+			ok = ok && counts[name] == 3
+		}
+		if ok {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("calls were answered by %v, want an even spread over %v", counts, want)
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+}
+
+func TestBackendBalancingRoundRobin(t *testing.T) {
+	replicas := startReplicas(t, 3)
+	client := dialReplicas(t, replicas)
+
+	awaitCalls(t, client, "a", "b", "c")
+}
+
+func TestBackendBalancingSkipsUnhealthyReplicas(t *testing.T) {
+	replicas := startReplicas(t, 3)
+	client := dialReplicas(t, replicas)
+	awaitCalls(t, client, "a", "b", "c")
+
+	// a draining replica reports NOT_SERVING before it stops
+	replicas[1].health.SetServingStatus("", healthpb.HealthCheckResponse_NOT_SERVING)
+	awaitCalls(t, client, "a", "c")
+
+	replicas[1].health.SetServingStatus("", healthpb.HealthCheckResponse_SERVING)
+	awaitCalls(t, client, "a", "b", "c")
+}
+
+func TestBackendBalancingFailsOverStoppedReplicas(t *testing.T) {
+	replicas := startReplicas(t, 3)
+	client := dialReplicas(t, replicas)
+	awaitCalls(t, client, "a", "b", "c")
+
+	replicas[0].server.Stop()
+	awaitCalls(t, client, "b", "c")
+}
@@ -0,0 +1,242 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"sort"
+
+	"github.com/Cohen-J-Omer/k8-task-mgmt-system/taskmgmt/internal/identity"
+	"github.com/Cohen-J-Omer/k8-task-mgmt-system/taskmgmt/internal/rbac"
+	"github.com/Cohen-J-Omer/k8-task-mgmt-system/taskmgmt/internal/validator"
+	pb "github.com/Cohen-J-Omer/k8-task-mgmt-system/taskmgmt/proto"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// access is the level of access to a task an operation requires.
+type access int
+
+const (
+	readAccess access = iota
+	writeAccess
+)
+
+// aclFilter returns the query restricting tasks to those the caller may access, so tasks the caller
+// can't see are filtered out by MongoDB rather than after loading them. Admins may access every task.
+func aclFilter(ctx context.Context, acc access) (bson.M, error) {
+	p, ok := identity.FromIncomingContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "caller is not identified")
+	}
+	if p.HasRole(rbac.Admin) {
+		return bson.M{}, nil
+	}
+	grants := bson.A{
+		bson.M{"acl.restricted": bson.M{"$ne": true}},
+		bson.M{"acl.ownerid": p.UserID},
+		bson.M{"acl.writerids": p.UserID},
+	}
+	if len(p.TeamIDs) > 0 {
+		grants = append(grants, bson.M{"acl.writerteamids": bson.M{"$in": p.TeamIDs}})
+	}
+	if acc == readAccess {
+		grants = append(grants, bson.M{"acl.readerids": p.UserID})
+		if len(p.TeamIDs) > 0 {
+			grants = append(grants, bson.M{"acl.readerteamids": bson.M{"$in": p.TeamIDs}})
+		}
+	}
+	return bson.M{"$or": grants}, nil
+}
+
+// taskFilter returns the query matching the task with the given ID, provided the caller may access it.
+func taskFilter(ctx context.Context, taskID string, acc access) (bson.M, error) {
+	filter, err := aclFilter(ctx, acc)
+	if err != nil {
+		return nil, err
+	}
+	filter["id"] = taskID
+	return filter, nil
+}
+
+// requireTaskAccess fails unless the task exists and the caller may access it.
+func (s *server) requireTaskAccess(ctx context.Context, taskID string, acc access) error {
+	filter, err := taskFilter(ctx, taskID, acc)
+	if err != nil {
+		return err
+	}
+	err = s.mongoCol.FindOne(ctx, filter, options.FindOne().SetProjection(bson.M{"_id": 1})).Err()
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return s.accessError(ctx, taskID, acc)
+	}
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to get task: %v", err)
+	}
+	return nil
+}
+
+// accessError explains why a task didn't match a query filtered by access: tasks the caller
+// can't read are reported as not found, so their existence isn't revealed.
+func (s *server) accessError(ctx context.Context, taskID string, acc access) error {
+	if acc == writeAccess {
+		if filter, err := taskFilter(ctx, taskID, readAccess); err == nil {
+			if s.mongoCol.FindOne(ctx, filter, options.FindOne().SetProjection(bson.M{"_id": 1})).Err() == nil {
+				return status.Errorf(codes.PermissionDenied, "no write access to task %s", taskID)
+			}
+		}
+	}
+	return status.Errorf(codes.NotFound, "task with id %s not found", taskID)
+}
+
+// validateACL checks that the users and teams an ACL grants access to exist.
+func (s *server) validateACL(ctx context.Context, acl *pb.TaskACL) error {
+	if err := validator.ValidateTaskACL(acl); err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	users := append(append([]string{}, acl.ReaderIds...), acl.WriterIds...)
+	if acl.OwnerId != "" {
+		users = append(users, acl.OwnerId)
+	}
+	if err := requireUsersExist(ctx, s.usersCol, users); err != nil {
+		return err
+	}
+	teams := append(append([]string{}, acl.ReaderTeamIds...), acl.WriterTeamIds...)
+	for _, id := range teams {
+		if err := s.teamsCol.FindOne(ctx, bson.M{"id": id}).Err(); err != nil {
+			if errors.Is(err, mongo.ErrNoDocuments) {
+				return status.Errorf(codes.InvalidArgument, "unknown team: %s", id)
+			}
+			return status.Errorf(codes.Internal, "failed to look up teams: %v", err)
+		}
+	}
+	return nil
+}
+
+// SetTaskACL replaces the task's access control list. Only the task's owner and admins may change it;
+// the owner stays unchanged unless a new one is given, transferring the task.
+func (s *server) SetTaskACL(ctx context.Context, req *pb.TaskACLRequest) (*pb.Task, error) {
+	p, ok := identity.FromIncomingContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "caller is not identified")
+	}
+	acl := req.Acl
+	if acl == nil {
+		acl = &pb.TaskACL{}
+	}
+	if err := s.validateACL(ctx, acl); err != nil {
+		return nil, err
+	}
+	filter, err := taskFilter(ctx, req.TaskId, readAccess)
+	if err != nil {
+		return nil, err
+	}
+	var task pb.Task
+	if err := s.mongoCol.FindOne(ctx, filter).Decode(&task); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, status.Errorf(codes.NotFound, "task with id %s not found", req.TaskId)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get task: %v", err)
+	}
+	owner := task.GetAcl().GetOwnerId()
+	if owner != p.UserID && !p.HasRole(rbac.Admin) {
+		return nil, status.Errorf(codes.PermissionDenied, "only the owner of task %s may change its access control list", req.TaskId)
+	}
+	if acl.OwnerId == "" {
+		acl.OwnerId = owner
+	}
+	// the whole ACL is replaced, as tasks created before ACLs existed have none to update
+	update := bson.M{"$set": bson.M{"acl": acl}}
+	err = s.mongoCol.FindOneAndUpdate(ctx, bson.M{"id": req.TaskId}, update, options.FindOneAndUpdate().SetReturnDocument(options.After)).Decode(&task)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, status.Errorf(codes.NotFound, "task with id %s not found", req.TaskId)
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to update task: %v", err)
+	}
+	return computeFields(&task), nil
+}
+
+// access levels ordered by the rights they grant
+var accessRank = map[string]int{"read": 1, "write": 2, "owner": 3}
+
+// GetTaskAccess lists the users granted access to a task, expanding its teams into their members.
+// An unrestricted task is accessible to its whole workspace, which comes first as its own entry.
+func (s *server) GetTaskAccess(ctx context.Context, req *pb.TaskID) (*pb.TaskAccess, error) {
+	var task pb.Task
+	if err := s.mongoCol.FindOne(ctx, bson.M{"id": req.Id}).Decode(&task); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, status.Errorf(codes.NotFound, "task with id %s not found", req.Id)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get task: %v", err)
+	}
+	acl := task.Acl
+	if acl == nil {
+		acl = &pb.TaskACL{}
+	}
+
+	entries := map[string]*pb.TaskAccessEntry{}
+	grant := func(userID, acc, via string) {
+		e, ok := entries[userID]
+		if !ok {
+			e = &pb.TaskAccessEntry{UserId: userID}
+			entries[userID] = e
+		}
+		if accessRank[acc] > accessRank[e.Access] {
+			e.Access = acc
+		}
+		e.Via = append(e.Via, via)
+	}
+	if acl.OwnerId != "" {
+		grant(acl.OwnerId, "owner", "owner")
+	}
+	for _, id := range acl.WriterIds {
+		grant(id, "write", "user")
+	}
+	for _, id := range acl.ReaderIds {
+		grant(id, "read", "user")
+	}
+	teamIDs := append(append([]string{}, acl.WriterTeamIds...), acl.ReaderTeamIds...)
+	if len(teamIDs) > 0 {
+		cursor, err := s.teamsCol.Find(ctx, bson.M{"id": bson.M{"$in": teamIDs}})
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to look up teams: %v", err)
+		}
+		var teams []*pb.Team
+		if err := cursor.All(ctx, &teams); err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to look up teams: %v", err)
+		}
+		writerTeams := map[string]bool{}
+		for _, id := range acl.WriterTeamIds {
+			writerTeams[id] = true
+		}
+		for _, team := range teams {
+			acc := "read"
+			if writerTeams[team.Id] {
+				acc = "write"
+			}
+			for _, member := range team.MemberIds {
+				grant(member, acc, "team:"+team.Id)
+			}
+		}
+	}
+
+	users := make([]*pb.TaskAccessEntry, 0, len(entries))
+	for _, e := range entries {
+		users = append(users, e)
+	}
+	sort.Slice(users, func(i, j int) bool { return users[i].UserId < users[j].UserId })
+
+	resp := &pb.TaskAccess{TaskId: task.Id, Acl: acl, Entries: []*pb.TaskAccessEntry{}}
+	if !acl.Restricted {
+		workspace, err := workspaceOf(ctx)
+		if err != nil {
+			return nil, err
+		}
+		resp.Entries = append(resp.Entries, &pb.TaskAccessEntry{WorkspaceId: workspace, Access: "write", Via: []string{"unrestricted"}})
+	}
+	resp.Entries = append(resp.Entries, users...)
+	return resp, nil
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"testing"
+
+	pb "github.com/Cohen-J-Omer/k8-task-mgmt-system/taskmgmt/proto"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+)
+
+func TestGetTaskAccessReportsUnrestrictedTasks(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	tests := []struct {
+		name       string
+		restricted bool
+		want       []*pb.TaskAccessEntry
+	}{
+		{
+			name: "unrestricted task is accessible to the workspace",
+			want: []*pb.TaskAccessEntry{
+				{WorkspaceId: "a", Access: "write", Via: []string{"unrestricted"}},
+				{UserId: "alice", Access: "owner", Via: []string{"owner"}},
+				{UserId: "bob", Access: "read", Via: []string{"user"}},
+			},
+		},
+		{
+			name:       "restricted task lists its users only",
+			restricted: true,
+			want: []*pb.TaskAccessEntry{
+				{UserId: "alice", Access: "owner", Via: []string{"owner"}},
+				{UserId: "bob", Access: "read", Via: []string{"user"}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		mt.Run(tt.name, func(mt *mtest.T) {
+			s := &server{mongoCol: &scopedCollection{col: mt.Coll}}
+			mt.AddMockResponses(mtest.CreateCursorResponse(1, "db.tasks", mtest.FirstBatch, bson.D{
+				{Key: "id", Value: "t1"},
+				{Key: "acl", Value: bson.D{
+					{Key: "ownerid", Value: "alice"},
+					{Key: "restricted", Value: tt.restricted},
+					{Key: "readerids", Value: bson.A{"bob"}},
+				}},
+			}))
+
+			access, err := s.GetTaskAccess(callerIn("a"), &pb.TaskID{Id: "t1"})
+			if err != nil {
+				mt.Fatalf("GetTaskAccess: %v", err)
+			}
+			if len(access.Entries) != len(tt.want) {
+				mt.Fatalf("GetTaskAccess returned %v, want %v", access.Entries, tt.want)
+			}
+			for i, e := range access.Entries {
+				w := tt.want[i]
+				if e.UserId != w.UserId || e.WorkspaceId != w.WorkspaceId || e.Access != w.Access || len(e.Via) != 1 || e.Via[0] != w.Via[0] {
+					mt.Errorf("entry %d is %v, want %v", i, e, w)
+				}
+			}
+		})
+	}
+}
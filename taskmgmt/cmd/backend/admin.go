@@ -0,0 +1,38 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/Cohen-J-Omer/k8-task-mgmt-system/taskmgmt/internal/logging"
+	pb "github.com/Cohen-J-Omer/k8-task-mgmt-system/taskmgmt/proto"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// implements gRPC's AdminServiceServer interface
+// adminServer administers the running backend. Only operators may use it.
+type adminServer struct {
+	pb.UnimplementedAdminServiceServer
+}
+
+// GetLogLevel returns the backend's log level.
+func (s *adminServer) GetLogLevel(ctx context.Context, _ *pb.Empty) (*pb.LogLevel, error) {
+	if err := requireOperator(ctx); err != nil {
+		return nil, err
+	}
+	return &pb.LogLevel{Level: logging.Level()}, nil
+}
+
+// SetLogLevel changes this replica's log level until it restarts or its LOG_LEVEL_FILE changes.
+func (s *adminServer) SetLogLevel(ctx context.Context, req *pb.LogLevel) (*pb.LogLevel, error) {
+	if err := requireOperator(ctx); err != nil {
+		return nil, err
+	}
+	if err := logging.SetLevel(req.Level); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	slog.WarnContext(ctx, "log level changed", "level", logging.Level())
+	return &pb.LogLevel{Level: logging.Level()}, nil
+}
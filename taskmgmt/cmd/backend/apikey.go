@@ -0,0 +1,228 @@
+package main
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"time"
+
+	"github.com/Cohen-J-Omer/k8-task-mgmt-system/taskmgmt/internal/auth"
+	"github.com/Cohen-J-Omer/k8-task-mgmt-system/taskmgmt/internal/rbac"
+	"github.com/Cohen-J-Omer/k8-task-mgmt-system/taskmgmt/internal/validator"
+	pb "github.com/Cohen-J-Omer/k8-task-mgmt-system/taskmgmt/proto"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// implements gRPC's AuthServiceServer interface
+// authServer manages the users' API keys and resolves presented keys to their owners.
+type authServer struct {
+	pb.UnimplementedAuthServiceServer
+	mongoCol *scopedCollection // collection handler for the "apikeys" MongoDB collection
+	usersCol *scopedCollection
+	teamsCol *scopedCollection
+}
+
+// storedAPIKey is an API key as stored in MongoDB, holding the key's hash rather than the key itself.
+type storedAPIKey struct {
+	ID        string `bson:"id"`
+	UserID    string `bson:"userid"`
+	Name      string `bson:"name"`
+	Prefix    string `bson:"prefix"`
+	Hash      string `bson:"hash"`
+	CreatedAt string `bson:"createdat"`
+	ExpiresAt string `bson:"expiresat"`
+	RevokedAt string `bson:"revokedat"`
+	// set by the scoped collection on insert
+	WorkspaceID string `bson:"workspaceid,omitempty"`
+}
+
+func (k *storedAPIKey) proto() *pb.APIKey {
+	return &pb.APIKey{
+		Id:        k.ID,
+		UserId:    k.UserID,
+		Name:      k.Name,
+		Prefix:    k.Prefix,
+		CreatedAt: k.CreatedAt,
+		ExpiresAt: k.ExpiresAt,
+		RevokedAt: k.RevokedAt,
+	}
+}
+
+// newAuthServer creates an authServer, ensuring key IDs and prefixes are unique.
+// Prefixes are unique across workspaces, as keys are looked up by prefix before their workspace is known.
+func newAuthServer(ctx context.Context, keys *mongo.Collection, users, teams *scopedCollection) (*authServer, error) {
+	_, err := keys.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "prefix", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	})
+	if err != nil {
+		return nil, err
+	}
+	scoped, err := newScopedCollection(ctx, keys, "id")
+	if err != nil {
+		return nil, err
+	}
+	return &authServer{mongoCol: scoped, usersCol: users, teamsCol: teams}, nil
+}
+
+// CreateAPIKey issues a new API key for an existing user.
+// The plaintext key is only part of this response, as just its hash is stored.
+func (s *authServer) CreateAPIKey(ctx context.Context, req *pb.APIKey) (*pb.APIKey, error) {
+	if err := validator.ValidateAPIKey(req); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err := requireUsersExist(ctx, s.usersCol, []string{req.UserId}); err != nil {
+		return nil, err
+	}
+	// retry in the unlikely case of a prefix collision
+	for attempt := 0; attempt < 3; attempt++ {
+		key, prefix, err := auth.GenerateAPIKey()
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to generate API key: %v", err)
+		}
+		stored := &storedAPIKey{
+			ID:        uuid.New().String(),
+			UserID:    req.UserId,
+			Name:      req.Name,
+			Prefix:    prefix,
+			Hash:      auth.HashAPIKey(key),
+			CreatedAt: time.Now().UTC().Format(time.RFC3339),
+			ExpiresAt: req.ExpiresAt,
+		}
+		if _, err := s.mongoCol.InsertOne(ctx, stored); err != nil {
+			if mongo.IsDuplicateKeyError(err) {
+				continue
+			}
+			return nil, status.Errorf(codes.Internal, "failed to create API key: %v", err)
+		}
+		resp := stored.proto()
+		resp.Key = key
+		return resp, nil
+	}
+	return nil, status.Error(codes.Internal, "failed to generate a unique API key")
+}
+
+// GetAPIKeys lists the API keys, optionally only those of one user, oldest first.
+func (s *authServer) GetAPIKeys(ctx context.Context, req *pb.APIKeyFilter) (*pb.APIKeyList, error) {
+	filter := bson.M{}
+	if req.UserId != "" {
+		filter["userid"] = req.UserId
+	}
+	cursor, err := s.mongoCol.Find(ctx, filter, options.Find().SetSort(bson.D{{Key: "createdat", Value: 1}}))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list API keys: %v", err)
+	}
+	var stored []*storedAPIKey
+	if err := cursor.All(ctx, &stored); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list API keys: %v", err)
+	}
+	list := &pb.APIKeyList{Keys: []*pb.APIKey{}}
+	for _, k := range stored {
+		list.Keys = append(list.Keys, k.proto())
+	}
+	return list, nil
+}
+
+// RevokeAPIKey revokes an API key. Revoking an already revoked key keeps its original revocation time.
+func (s *authServer) RevokeAPIKey(ctx context.Context, req *pb.APIKeyID) (*pb.APIKey, error) {
+	var stored storedAPIKey
+	update := bson.M{"$set": bson.M{"revokedat": time.Now().UTC().Format(time.RFC3339)}}
+	err := s.mongoCol.FindOneAndUpdate(ctx, bson.M{"id": req.Id, "revokedat": ""}, update, options.FindOneAndUpdate().SetReturnDocument(options.After)).Decode(&stored)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		err = s.mongoCol.FindOne(ctx, bson.M{"id": req.Id}).Decode(&stored)
+	}
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, status.Errorf(codes.NotFound, "API key %s not found", req.Id)
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to revoke API key: %v", err)
+	}
+	return stored.proto(), nil
+}
+
+// AuthenticateAPIKey resolves a presented API key to its owner and the owner's workspace, teams and role,
+// along with the key's ID.
+func (s *authServer) AuthenticateAPIKey(ctx context.Context, req *pb.APIKeySecret) (*pb.Principal, error) {
+	invalid := status.Error(codes.Unauthenticated, "invalid API key")
+	prefix, ok := auth.APIKeyPrefix(req.Key)
+	if !ok {
+		return nil, invalid
+	}
+	// the key determines the caller's workspace, so it is looked up across all workspaces,
+	// and its owner within the key's workspace
+	var stored storedAPIKey
+	if err := s.mongoCol.Unscoped().FindOne(ctx, bson.M{"prefix": prefix}).Decode(&stored); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, invalid
+		}
+		return nil, status.Errorf(codes.Internal, "failed to look up API key: %v", err)
+	}
+	if subtle.ConstantTimeCompare([]byte(auth.HashAPIKey(req.Key)), []byte(stored.Hash)) != 1 {
+		return nil, invalid
+	}
+	if stored.RevokedAt != "" {
+		return nil, invalid
+	}
+	// expiry dates are stored in UTC, so they compare as strings
+	if stored.ExpiresAt != "" && stored.ExpiresAt <= time.Now().UTC().Format(time.RFC3339) {
+		return nil, invalid
+	}
+
+	principal, err := s.principalOf(ctx, stored.WorkspaceID, stored.UserID)
+	if status.Code(err) == codes.NotFound {
+		return nil, invalid
+	}
+	if err != nil {
+		return nil, err
+	}
+	principal.ApiKeyId = stored.ID
+	return principal, nil
+}
+
+// ResolveUser resolves a user of the caller's workspace to their principal.
+func (s *authServer) ResolveUser(ctx context.Context, req *pb.UserID) (*pb.Principal, error) {
+	workspace, err := workspaceOf(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.principalOf(ctx, workspace, req.Id)
+}
+
+// principalOf returns the principal of a user of the workspace, with the user's teams and role, failing
+// with NotFound if the user doesn't exist. The workspace is given rather than taken from the caller, so
+// it is looked up unscoped.
+func (s *authServer) principalOf(ctx context.Context, workspaceID, userID string) (*pb.Principal, error) {
+	var user pb.User
+	inWorkspace := bson.M{"id": userID, workspaceField: workspaceID}
+	if err := s.usersCol.Unscoped().FindOne(ctx, inWorkspace).Decode(&user); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, status.Errorf(codes.NotFound, "user %s not found", userID)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to look up user: %v", err)
+	}
+	role := user.Role
+	// users created before roles were introduced are members
+	if role == "" {
+		role = rbac.Member
+	}
+	inWorkspace = bson.M{"memberids": userID, workspaceField: workspaceID}
+	cursor, err := s.teamsCol.Unscoped().Find(ctx, inWorkspace, options.Find().SetProjection(bson.M{"id": 1}))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to look up teams: %v", err)
+	}
+	var teams []*pb.Team
+	if err := cursor.All(ctx, &teams); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to look up teams: %v", err)
+	}
+	principal := &pb.Principal{UserId: userID, TeamIds: []string{}, Roles: []string{role}, WorkspaceId: workspaceID}
+	for _, t := range teams {
+		principal.TeamIds = append(principal.TeamIds, t.Id)
+	}
+	return principal, nil
+}
@@ -0,0 +1,241 @@
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"log/slog"
+	"time"
+
+	"github.com/Cohen-J-Omer/k8-task-mgmt-system/taskmgmt/internal/blobstore"
+	"github.com/Cohen-J-Omer/k8-task-mgmt-system/taskmgmt/internal/validator"
+	pb "github.com/Cohen-J-Omer/k8-task-mgmt-system/taskmgmt/proto"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// attachmentChunkSize is the size of the content chunks streamed to the API.
+const attachmentChunkSize = 64 * 1024
+
+var errAttachmentTooLarge = errors.New("attachment exceeds maximum size")
+
+// attachmentKey returns the blob store key of an attachment of a task in the caller's workspace.
+// Keys start with the workspace, so content of different workspaces never shares a key.
+func attachmentKey(ctx context.Context, taskID, attachmentID string) (string, error) {
+	workspace, err := workspaceOf(ctx)
+	if err != nil {
+		return "", err
+	}
+	return workspace + "/" + taskID + "/" + attachmentID, nil
+}
+
+// legacyAttachmentKey returns the key attachments uploaded before keys started with the workspace
+// are still stored under. They are read and deleted under it when no blob has the new key.
+func legacyAttachmentKey(taskID, attachmentID string) string {
+	return taskID + "/" + attachmentID
+}
+
+// openAttachment opens the content of an attachment, falling back to its legacy key.
+func (s *server) openAttachment(ctx context.Context, taskID, attachmentID string, offset, length int64) (io.ReadCloser, error) {
+	key, err := attachmentKey(ctx, taskID, attachmentID)
+	if err != nil {
+		return nil, err
+	}
+	r, err := s.blobs.Get(ctx, key, offset, length)
+	if errors.Is(err, blobstore.ErrNotFound) {
+		return s.blobs.Get(ctx, legacyAttachmentKey(taskID, attachmentID), offset, length)
+	}
+	return r, err
+}
+
+// chunkReader exposes the content of an UploadAttachment stream as an io.Reader,
+// failing once more than limit bytes were received.
+type chunkReader struct {
+	stream pb.TaskService_UploadAttachmentServer
+	buf    []byte
+	n      int64
+	limit  int64
+}
+
+func (r *chunkReader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		chunk, err := r.stream.Recv()
+		if err != nil {
+			return 0, err // io.EOF once the client closed the stream
+		}
+		r.buf = chunk.Data
+	}
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	r.n += int64(n)
+	if r.n > r.limit {
+		return 0, errAttachmentTooLarge
+	}
+	return n, nil
+}
+
+// UploadAttachment stores the streamed content in the blob store and records its metadata on the task.
+func (s *server) UploadAttachment(stream pb.TaskService_UploadAttachmentServer) error {
+	ctx := stream.Context()
+	first, err := stream.Recv()
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "missing attachment metadata: %v", err)
+	}
+	meta := first.GetMetadata()
+	if first.TaskId == "" || meta == nil || meta.Name == "" {
+		return status.Error(codes.InvalidArgument, "task_id and attachment name are required")
+	}
+	// make sure the task exists and may be written before storing any content
+	if err := s.requireTaskAccess(ctx, first.TaskId, writeAccess); err != nil {
+		return err
+	}
+
+	att := &pb.Attachment{
+		Id:          uuid.New().String(),
+		Name:        meta.Name,
+		ContentType: meta.ContentType,
+		CreatedAt:   time.Now().UTC().Format(time.RFC3339),
+	}
+	key, err := attachmentKey(ctx, first.TaskId, att.Id)
+	if err != nil {
+		return err
+	}
+	hash := sha256.New()
+	content := &chunkReader{stream: stream, buf: first.Data, limit: s.maxAttachmentBytes}
+	if err := s.blobs.Put(ctx, key, io.TeeReader(content, hash)); err != nil {
+		if errors.Is(err, errAttachmentTooLarge) {
+			st := status.Newf(codes.InvalidArgument, "attachment exceeds maximum size of %d bytes", s.maxAttachmentBytes)
+			if detailed, err := st.WithDetails(&errdetails.ErrorInfo{Reason: validator.AttachmentTooLargeReason, Domain: "taskmgmt"}); err == nil {
+				st = detailed
+			}
+			return st.Err()
+		}
+		return status.Errorf(codes.Internal, "failed to store attachment: %v", err)
+	}
+	att.Size = content.n
+	att.Sha256 = hex.EncodeToString(hash.Sum(nil))
+
+	res, err := s.mongoCol.UpdateOne(ctx, bson.M{"id": first.TaskId}, bson.M{"$push": bson.M{"attachments": att}})
+	if err != nil || res.MatchedCount == 0 {
+		// the task is gone or unreachable, don't leave an orphaned blob behind
+		if delErr := s.blobs.Delete(context.WithoutCancel(ctx), key); delErr != nil {
+			slog.ErrorContext(ctx, "failed to delete orphaned attachment", "key", key, "error", delErr)
+		}
+		if err != nil {
+			return status.Errorf(codes.Internal, "failed to save attachment: %v", err)
+		}
+		return status.Errorf(codes.NotFound, "task with id %s not found", first.TaskId)
+	}
+	return stream.SendAndClose(att)
+}
+
+// findAttachment returns the metadata of an attachment of a task.
+func (s *server) findAttachment(ctx context.Context, taskID, attachmentID string) (*pb.Attachment, error) {
+	var task pb.Task
+	err := s.mongoCol.FindOne(ctx, bson.M{"id": taskID, "attachments.id": attachmentID}).Decode(&task)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, status.Errorf(codes.NotFound, "attachment %s of task %s not found", attachmentID, taskID)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get task: %v", err)
+	}
+	for _, att := range task.Attachments {
+		if att.Id == attachmentID {
+			return att, nil
+		}
+	}
+	return nil, status.Errorf(codes.NotFound, "attachment %s of task %s not found", attachmentID, taskID)
+}
+
+// DownloadAttachment streams the requested byte range of an attachment's content.
+func (s *server) DownloadAttachment(req *pb.AttachmentRange, stream pb.TaskService_DownloadAttachmentServer) error {
+	ctx := stream.Context()
+	if err := s.requireTaskAccess(ctx, req.TaskId, readAccess); err != nil {
+		return err
+	}
+	att, err := s.findAttachment(ctx, req.TaskId, req.AttachmentId)
+	if err != nil {
+		return err
+	}
+	if req.Offset < 0 || req.Offset > att.Size {
+		return status.Errorf(codes.OutOfRange, "offset %d is outside of attachment of size %d", req.Offset, att.Size)
+	}
+	r, err := s.openAttachment(ctx, req.TaskId, att.Id, req.Offset, req.Length)
+	if err != nil {
+		if errors.Is(err, blobstore.ErrNotFound) {
+			return status.Errorf(codes.NotFound, "content of attachment %s not found", att.Id)
+		}
+		return status.Errorf(codes.Internal, "failed to read attachment: %v", err)
+	}
+	defer r.Close()
+
+	buf := make([]byte, attachmentChunkSize)
+	for {
+		n, err := r.Read(buf)
+		if n > 0 {
+			if sendErr := stream.Send(&pb.AttachmentChunk{Data: buf[:n]}); sendErr != nil {
+				return sendErr
+			}
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return status.Errorf(codes.Internal, "failed to read attachment: %v", err)
+		}
+	}
+}
+
+// DeleteAttachment removes an attachment's metadata from its task and its content from the blob store.
+func (s *server) DeleteAttachment(ctx context.Context, req *pb.AttachmentID) (*pb.Attachment, error) {
+	if err := s.requireTaskAccess(ctx, req.TaskId, writeAccess); err != nil {
+		return nil, err
+	}
+	var task pb.Task
+	filter := bson.M{"id": req.TaskId, "attachments.id": req.AttachmentId}
+	update := bson.M{"$pull": bson.M{"attachments": bson.M{"id": req.AttachmentId}}}
+	// the document before the update still holds the removed attachment's metadata
+	err := s.mongoCol.FindOneAndUpdate(ctx, filter, update, options.FindOneAndUpdate().SetReturnDocument(options.Before)).Decode(&task)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, status.Errorf(codes.NotFound, "attachment %s of task %s not found", req.AttachmentId, req.TaskId)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to delete attachment: %v", err)
+	}
+	s.deleteAttachmentBlobs(ctx, req.TaskId, task.Attachments, req.AttachmentId)
+	for _, att := range task.Attachments {
+		if att.Id == req.AttachmentId {
+			return att, nil
+		}
+	}
+	return &pb.Attachment{Id: req.AttachmentId}, nil
+}
+
+// deleteAttachmentBlobs removes the content of the given attachments from the blob store,
+// restricted to the attachment with id only when only is not empty.
+// Failures are logged rather than returned, as the metadata is already gone at this point.
+func (s *server) deleteAttachmentBlobs(ctx context.Context, taskID string, attachments []*pb.Attachment, only string) {
+	for _, att := range attachments {
+		if only != "" && att.Id != only {
+			continue
+		}
+		keys := []string{legacyAttachmentKey(taskID, att.Id)}
+		if key, err := attachmentKey(ctx, taskID, att.Id); err == nil {
+			keys = append(keys, key)
+		}
+		// deleting a missing blob is not an error, so both keys are deleted
+		for _, key := range keys {
+			if err := s.blobs.Delete(context.WithoutCancel(ctx), key); err != nil {
+				slog.ErrorContext(ctx, "failed to delete attachment", "key", key, "error", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/Cohen-J-Omer/k8-task-mgmt-system/taskmgmt/internal/blobstore"
+	pb "github.com/Cohen-J-Omer/k8-task-mgmt-system/taskmgmt/proto"
+)
+
+// memStore is a BlobStore keeping blobs in memory.
+type memStore map[string][]byte
+
+func (m memStore) Put(_ context.Context, key string, r io.Reader) error {
+	b, err := io.ReadAll(r)
+	m[key] = b
+	return err
+}
+
+func (m memStore) Get(_ context.Context, key string, offset, length int64) (io.ReadCloser, error) {
+	b, ok := m[key]
+	if !ok {
+		return nil, blobstore.ErrNotFound
+	}
+	b = b[offset:]
+	if length > 0 {
+		b = b[:min(length, int64(len(b)))]
+	}
+	return io.NopCloser(bytes.NewReader(b)), nil
+}
+
+func (m memStore) Delete(_ context.Context, key string) error {
+	delete(m, key)
+	return nil
+}
+
+func TestAttachmentKeysAreScopedToTheWorkspace(t *testing.T) {
+	a, err := attachmentKey(callerIn("a"), "t1", "att1")
+	if err != nil {
+		t.Fatalf("attachmentKey: %v", err)
+	}
+	b, _ := attachmentKey(callerIn("b"), "t1", "att1")
+	if a == b || !strings.HasPrefix(a, "a/") {
+		t.Errorf("keys of workspaces a and b are %q and %q, want distinct keys starting with the workspace", a, b)
+	}
+	if _, err := attachmentKey(context.Background(), "t1", "att1"); err == nil {
+		t.Error("attachmentKey accepted a caller without a workspace")
+	}
+}
+
+func TestLegacyAttachmentKeys(t *testing.T) {
+	ctx := callerIn("a")
+	blobs := memStore{legacyAttachmentKey("t1", "old"): []byte("legacy content")}
+	s := &server{blobs: blobs}
+
+	r, err := s.openAttachment(ctx, "t1", "old", 7, 0)
+	if err != nil {
+		t.Fatalf("legacy attachment wasn't found: %v", err)
+	}
+	if b, _ := io.ReadAll(r); string(b) != "content" {
+		t.Errorf("legacy attachment read %q, want %q", b, "content")
+	}
+
+	key, _ := attachmentKey(ctx, "t1", "new")
+	blobs[key] = []byte("new content")
+	blobs[legacyAttachmentKey("t1", "new")] = []byte("stale")
+	r, err = s.openAttachment(ctx, "t1", "new", 0, 0)
+	if err != nil {
+		t.Fatalf("attachment wasn't found: %v", err)
+	}
+	if b, _ := io.ReadAll(r); string(b) != "new content" {
+		t.Errorf("attachment read %q, want the content under its workspace key", b)
+	}
+
+	s.deleteAttachmentBlobs(ctx, "t1", []*pb.Attachment{{Id: "old"}, {Id: "new"}}, "")
+	if len(blobs) != 0 {
+		t.Errorf("blobs %v were left behind", blobs)
+	}
+}
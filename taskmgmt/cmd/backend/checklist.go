@@ -0,0 +1,163 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/Cohen-J-Omer/k8-task-mgmt-system/taskmgmt/internal/validator"
+	pb "github.com/Cohen-J-Omer/k8-task-mgmt-system/taskmgmt/proto"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// checklistRetries bounds the attempts of a read-modify-write checklist update
+// losing the race against concurrent updates of the same checklist.
+const checklistRetries = 3
+
+// checklistProgress returns the percentage of done items of a checklist, 0 for an empty checklist.
+func checklistProgress(items []*pb.ChecklistItem) int32 {
+	if len(items) == 0 {
+		return 0
+	}
+	done := 0
+	for _, item := range items {
+		if item.Done {
+			done++
+		}
+	}
+	return int32(done * 100 / len(items))
+}
+
+// AddChecklistItem appends a new item to the task's checklist.
+func (s *server) AddChecklistItem(ctx context.Context, req *pb.ChecklistItemRequest) (*pb.Task, error) {
+	if err := s.requireTaskAccess(ctx, req.TaskId, writeAccess); err != nil {
+		return nil, err
+	}
+	if err := validator.ValidateChecklistItem(req.Item); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	item := &pb.ChecklistItem{Id: uuid.New().String(), Text: req.Item.Text, Done: req.Item.Done}
+	// only match tasks whose checklist still has room for another item
+	filter := bson.M{"id": req.TaskId, fmt.Sprintf("checklist.%d", validator.MaxChecklistItems-1): bson.M{"$exists": false}}
+	update := bson.M{"$push": bson.M{"checklist": item}}
+	var task pb.Task
+	err := s.mongoCol.FindOneAndUpdate(ctx, filter, update, options.FindOneAndUpdate().SetReturnDocument(options.After)).Decode(&task)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		if err := s.mongoCol.FindOne(ctx, bson.M{"id": req.TaskId}).Err(); err == nil {
+			return nil, status.Errorf(codes.FailedPrecondition, "checklist must have at most %d items", validator.MaxChecklistItems)
+		}
+		return nil, status.Errorf(codes.NotFound, "task with id %s not found", req.TaskId)
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to add checklist item: %v", err)
+	}
+	return computeFields(&task), nil
+}
+
+// ToggleChecklistItem flips the done state of a checklist item.
+// Completing the last open item also completes the task when its complete_on_checklist_done flag is set.
+func (s *server) ToggleChecklistItem(ctx context.Context, req *pb.ChecklistItemID) (*pb.Task, error) {
+	return s.modifyChecklist(ctx, req.TaskId, func(task *pb.Task) error {
+		for _, item := range task.Checklist {
+			if item.Id == req.ItemId {
+				item.Done = !item.Done
+				if item.Done && task.CompleteOnChecklistDone && checklistProgress(task.Checklist) == 100 {
+					task.Completed = true
+				}
+				return nil
+			}
+		}
+		return status.Errorf(codes.NotFound, "checklist item %s of task %s not found", req.ItemId, req.TaskId)
+	})
+}
+
+// ReorderChecklist reorders the task's checklist according to the given item IDs,
+// which must list every item of the checklist exactly once.
+func (s *server) ReorderChecklist(ctx context.Context, req *pb.ChecklistOrder) (*pb.Task, error) {
+	return s.modifyChecklist(ctx, req.TaskId, func(task *pb.Task) error {
+		if len(req.ItemIds) != len(task.Checklist) {
+			return status.Errorf(codes.InvalidArgument, "item_ids must list all %d checklist items", len(task.Checklist))
+		}
+		byID := make(map[string]*pb.ChecklistItem, len(task.Checklist))
+		for _, item := range task.Checklist {
+			byID[item.Id] = item
+		}
+		ordered := make([]*pb.ChecklistItem, 0, len(req.ItemIds))
+		for _, id := range req.ItemIds {
+			item, ok := byID[id]
+			if !ok {
+				return status.Errorf(codes.InvalidArgument, "unknown or duplicate checklist item %s", id)
+			}
+			delete(byID, id)
+			ordered = append(ordered, item)
+		}
+		task.Checklist = ordered
+		return nil
+	})
+}
+
+// RemoveChecklistItem removes an item from the task's checklist.
+func (s *server) RemoveChecklistItem(ctx context.Context, req *pb.ChecklistItemID) (*pb.Task, error) {
+	if err := s.requireTaskAccess(ctx, req.TaskId, writeAccess); err != nil {
+		return nil, err
+	}
+	filter := bson.M{"id": req.TaskId, "checklist.id": req.ItemId}
+	update := bson.M{"$pull": bson.M{"checklist": bson.M{"id": req.ItemId}}}
+	var task pb.Task
+	err := s.mongoCol.FindOneAndUpdate(ctx, filter, update, options.FindOneAndUpdate().SetReturnDocument(options.After)).Decode(&task)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, status.Errorf(codes.NotFound, "checklist item %s of task %s not found", req.ItemId, req.TaskId)
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to remove checklist item: %v", err)
+	}
+	return computeFields(&task), nil
+}
+
+// modifyChecklist applies modify to the stored task and saves its checklist and completed state,
+// materializing the next occurrence of recurring tasks it completes.
+// The update only matches while the checklist is unchanged since it was read, and is retried otherwise,
+// so concurrent checklist updates never overwrite each other.
+func (s *server) modifyChecklist(ctx context.Context, taskID string, modify func(task *pb.Task) error) (*pb.Task, error) {
+	if err := s.requireTaskAccess(ctx, taskID, writeAccess); err != nil {
+		return nil, err
+	}
+	for attempt := 0; attempt < checklistRetries; attempt++ {
+		var task pb.Task
+		if err := s.mongoCol.FindOne(ctx, bson.M{"id": taskID}).Decode(&task); err != nil {
+			if errors.Is(err, mongo.ErrNoDocuments) {
+				return nil, status.Errorf(codes.NotFound, "task with id %s not found", taskID)
+			}
+			return nil, status.Errorf(codes.Internal, "failed to get task: %v", err)
+		}
+		// snapshot the stored checklist before modify mutates its items
+		stored, err := bson.Marshal(bson.M{"checklist": task.Checklist})
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to encode checklist: %v", err)
+		}
+		if err := modify(&task); err != nil {
+			return nil, err
+		}
+		filter := bson.M{"id": taskID, "checklist": bson.Raw(stored).Lookup("checklist")}
+		update := bson.M{"$set": bson.M{"checklist": task.Checklist, "completed": task.Completed}}
+		res, err := s.mongoCol.UpdateOne(ctx, filter, update)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to update checklist: %v", err)
+		}
+		if res.MatchedCount == 1 {
+			s.recordHistory(ctx, &task, false)
+			if err := s.materializeNextOccurrence(ctx, &task); err != nil {
+				slog.ErrorContext(ctx, "failed to create next occurrence", "task_id", task.Id, "error", err)
+			}
+			return computeFields(&task), nil
+		}
+	}
+	return nil, status.Errorf(codes.Aborted, "checklist of task %s was modified concurrently, please retry", taskID)
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// maxTime returns the time left until the deadline of the request, which the API propagates, for
+// MongoDB to stop operations the caller won't wait for anymore. ok is false without deadline.
+func maxTime(ctx context.Context) (time.Duration, bool) {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return 0, false
+	}
+	// MongoDB rounds to milliseconds, treating 0 as no limit
+	return max(time.Until(deadline), time.Millisecond), true
+}
+
+// deadlineError reports a failure caused by the request's deadline as DeadlineExceeded rather than
+// Internal, which the handlers report failed MongoDB operations as.
+func deadlineError(ctx context.Context, err error) error {
+	if err == nil || !errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return err
+	}
+	switch status.Code(err) {
+	case codes.Internal, codes.Unknown:
+		return status.Error(codes.DeadlineExceeded, "deadline exceeded: "+status.Convert(err).Message())
+	}
+	return err
+}
+
+// deadlineUnaryInterceptor applies deadlineError to unary RPCs.
+func deadlineUnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		resp, err := handler(ctx, req)
+		return resp, deadlineError(ctx, err)
+	}
+}
+
+// deadlineStreamInterceptor applies deadlineError to streaming RPCs.
+func deadlineStreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return deadlineError(ss.Context(), handler(srv, ss))
+	}
+}
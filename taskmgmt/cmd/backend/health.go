@@ -0,0 +1,58 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// Health service names reported besides the overall status "", which is SERVING only while all
+// dependencies are: livenessService is SERVING as long as the process runs, so a dependency's outage
+// doesn't get the backend restarted, and mongoService reports whether MongoDB answers pings.
+const (
+	livenessService = "liveness"
+	mongoService    = "mongodb"
+)
+
+// pingMongo reports whether MongoDB answers a ping within timeout.
+func pingMongo(ctx context.Context, client *mongo.Client, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	return client.Ping(ctx, nil)
+}
+
+// watchMongo pings MongoDB every interval until ctx is done, flipping the health of the mongodb
+// service and the overall health between SERVING and NOT_SERVING. Changes are logged.
+func watchMongo(ctx context.Context, client *mongo.Client, healthServer *health.Server, interval, timeout time.Duration) {
+	healthy := true
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		err := pingMongo(ctx, client, timeout)
+		if ctx.Err() != nil {
+			return
+		}
+		if (err == nil) == healthy {
+			continue
+		}
+		healthy = err == nil
+		status := healthpb.HealthCheckResponse_SERVING
+		if healthy {
+			slog.Info("MongoDB is reachable again, serving")
+		} else {
+			status = healthpb.HealthCheckResponse_NOT_SERVING
+			slog.Error("MongoDB is unreachable, not serving", "error", err)
+		}
+		healthServer.SetServingStatus(mongoService, status)
+		healthServer.SetServingStatus("", status)
+	}
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"time"
+
+	"github.com/Cohen-J-Omer/k8-task-mgmt-system/taskmgmt/internal/config"
+	"github.com/Cohen-J-Omer/k8-task-mgmt-system/taskmgmt/internal/loadshed"
+	pb "github.com/Cohen-J-Omer/k8-task-mgmt-system/taskmgmt/proto"
+)
+
+// listMethods scan whole collections or a large part of them, and are shed first under load.
+var listMethods = map[string]bool{
+	pb.TaskService_GetTasks_FullMethodName:           true,
+	pb.TaskService_ListOccurrences_FullMethodName:    true,
+	pb.TaskService_GetTimeReport_FullMethodName:      true,
+	pb.TemplateService_GetTemplates_FullMethodName:   true,
+	pb.UserService_GetUsers_FullMethodName:           true,
+	pb.UserService_GetTeams_FullMethodName:           true,
+	pb.AuthService_GetAPIKeys_FullMethodName:         true,
+	pb.ProjectService_GetProjects_FullMethodName:     true,
+	pb.ProjectService_GetBoard_FullMethodName:        true,
+	pb.ProjectService_GetBoards_FullMethodName:       true,
+	pb.MilestoneService_GetMilestones_FullMethodName: true,
+	pb.MilestoneService_GetBurndown_FullMethodName:   true,
+	pb.WorkspaceService_GetWorkspaces_FullMethodName: true,
+}
+
+// newLoadShedder returns the backend's concurrency limiter, configured by CONCURRENCY_LIMIT (its
+// initial limit), CONCURRENCY_LIMIT_MIN and CONCURRENCY_LIMIT_MAX, CONCURRENCY_LATENCY_TARGET_MS and
+// CONCURRENCY_LOW_PRIORITY_PERCENT, the share of the limit list scans may use.
+func newLoadShedder() *loadshed.Limiter {
+	return loadshed.NewLimiter(loadshed.Config{
+		Initial:          int(config.GetEnvInt64("CONCURRENCY_LIMIT", 20)),
+		Min:              int(config.GetEnvInt64("CONCURRENCY_LIMIT_MIN", 4)),
+		Max:              int(config.GetEnvInt64("CONCURRENCY_LIMIT_MAX", 200)),
+		LatencyTarget:    time.Duration(config.GetEnvInt64("CONCURRENCY_LATENCY_TARGET_MS", 250)) * time.Millisecond,
+		LowPriorityShare: float64(config.GetEnvInt64("CONCURRENCY_LOW_PRIORITY_PERCENT", 50)) / 100,
+		LowPriority:      listMethods,
+		// probes must be answered whatever the load
+		Exempt: map[string]bool{"/grpc.health.v1.Health/Check": true},
+	})
+}
@@ -2,26 +2,39 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
+	"log/slog"
 	"net"
+	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
 	"time"
 
-	pb "github.com/Cohen-J-Omer/k8-task-mgmt-system/taskmgmt/proto"
+	"github.com/Cohen-J-Omer/k8-task-mgmt-system/taskmgmt/internal/blobstore"
 	"github.com/Cohen-J-Omer/k8-task-mgmt-system/taskmgmt/internal/config"
+	"github.com/Cohen-J-Omer/k8-task-mgmt-system/taskmgmt/internal/logging"
+	"github.com/Cohen-J-Omer/k8-task-mgmt-system/taskmgmt/internal/metrics"
+	"github.com/Cohen-J-Omer/k8-task-mgmt-system/taskmgmt/internal/mtls"
+	"github.com/Cohen-J-Omer/k8-task-mgmt-system/taskmgmt/internal/rbac"
+	"github.com/Cohen-J-Omer/k8-task-mgmt-system/taskmgmt/internal/svcauth"
+	"github.com/Cohen-J-Omer/k8-task-mgmt-system/taskmgmt/internal/tracing"
+	pb "github.com/Cohen-J-Omer/k8-task-mgmt-system/taskmgmt/proto"
 
 	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/health"
 	"google.golang.org/grpc/codes"
-    "google.golang.org/grpc/status"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
 	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/keepalive"
+	"google.golang.org/grpc/status"
 )
 
 // implements gRPC's TaskServiceServer interface
@@ -29,39 +42,128 @@ import (
 // It connects to a MongoDB database to store and retrieve tasks.
 type server struct {
 	pb.UnimplementedTaskServiceServer
-	mongoCol *mongo.Collection  // collection handler for the "tasks" MongoDB collection
+	mongoCol           *scopedCollection   // collection handler for the "tasks" MongoDB collection
+	usersCol           *scopedCollection   // collection handler for the "users" MongoDB collection
+	teamsCol           *scopedCollection   // collection handler for the "teams" MongoDB collection
+	projectsCol        *scopedCollection   // collection handler for the "projects" MongoDB collection
+	milestonesCol      *scopedCollection   // collection handler for the "milestones" MongoDB collection
+	historyCol         *scopedCollection   // collection handler for the "taskhistory" MongoDB collection
+	blobs              blobstore.BlobStore // stores the content of task attachments
+	maxAttachmentBytes int64               // maximum size of a single attachment
+	maxTasks           int64               // maximum number of tasks of a workspace, unlimited if 0
 }
 
-// CreateTask creates a new task in the MongoDB collection.
+// CreateTask creates a new task in the MongoDB collection, owned by the caller.
 func (s *server) CreateTask(ctx context.Context, req *pb.Task) (*pb.Task, error) {
-    req.Id = uuid.New().String() // Generate a new UUID for the task ID
-    _, err := s.mongoCol.InsertOne(ctx, req)
-    if err != nil {
-        return nil, status.Errorf(codes.Internal, "failed to create task: %v", err)
-    }
-    return req, nil
+	owner, err := resolveUser(ctx, "me")
+	if err != nil {
+		return nil, err
+	}
+	if req.Acl == nil {
+		req.Acl = &pb.TaskACL{}
+	}
+	req.Acl.OwnerId = owner
+	if err := s.validateACL(ctx, req.Acl); err != nil {
+		return nil, err
+	}
+	// "me" refers to the calling user
+	for _, ids := range [][]string{req.AssigneeIds, req.WatcherIds} {
+		for i, id := range ids {
+			userID, err := resolveUser(ctx, id)
+			if err != nil {
+				return nil, err
+			}
+			ids[i] = userID
+		}
+	}
+	if err := requireUsersExist(ctx, s.usersCol, append(append([]string{}, req.AssigneeIds...), req.WatcherIds...)); err != nil {
+		return nil, err
+	}
+	if req.ProjectId != "" {
+		if err := requireProjectExists(ctx, s.projectsCol, req.ProjectId); err != nil {
+			return nil, err
+		}
+	}
+	if req.MilestoneId != "" {
+		if err := requireMilestoneExists(ctx, s.milestonesCol, req.MilestoneId); err != nil {
+			return nil, err
+		}
+	}
+	req.Id = uuid.New().String()                    // Generate a new UUID for the task ID
+	req.Attachments = nil                           // attachments are only added through UploadAttachment
+	req.ChecklistProgress, req.LoggedSeconds = 0, 0 // computed on read, never stored
+	req.Worklogs, req.Timers = nil, nil             // time is only tracked through the timer and worklog RPCs
+	req.Rank = ""                                   // cards are only ranked by moving them on a board
+	for _, item := range req.Checklist {
+		item.Id = uuid.New().String()
+	}
+	// a recurring task starts a new series
+	req.RecurrenceId, req.Occurrence, req.NextOccurrenceId = "", 0, ""
+	if req.Recurrence != "" {
+		req.RecurrenceId, req.Occurrence = req.Id, 1
+	}
+	if full, err := s.atTaskQuota(ctx, 1); err != nil {
+		return nil, err
+	} else if full {
+		return nil, s.taskQuotaError(ctx)
+	}
+	_, err = s.mongoCol.InsertOne(ctx, req)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to create task: %v", err)
+	}
+	s.recordHistory(ctx, req, false)
+	return computeFields(req), nil
 }
 
 // GetTask retrieves a task by its ID from the MongoDB collection.
 func (s *server) GetTask(ctx context.Context, req *pb.TaskID) (*pb.Task, error) {
-    var task pb.Task
-    err := s.mongoCol.FindOne(ctx, bson.M{"id": req.Id}).Decode(&task)
-	// If the task is not found, return a NotFound error
-    if err != nil {
-        return nil, status.Errorf(codes.NotFound, "task with id %s not found", req.Id)
-    }
-    return &task, nil
+	filter, err := taskFilter(ctx, req.Id, readAccess)
+	if err != nil {
+		return nil, err
+	}
+	var task pb.Task
+	err = s.mongoCol.FindOne(ctx, filter).Decode(&task)
+	// If the task is not found or the caller may not see it, return a NotFound error
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "task with id %s not found", req.Id)
+	}
+	return computeFields(&task), nil
 }
 
-// GetTasks retrieves all tasks from the MongoDB collection.
-func (s *server) GetTasks(ctx context.Context, _ *pb.Empty) (*pb.TaskList, error) {
-    cursor, err := s.mongoCol.Find(ctx, bson.M{})
-    if err != nil {
-        return nil, status.Errorf(codes.Internal, "failed to list tasks: %v", err)
-    }
+// GetTasks retrieves all tasks the caller may see from the MongoDB collection,
+// optionally restricted to those assigned to or watched by a user, or of a project or milestone.
+func (s *server) GetTasks(ctx context.Context, req *pb.TaskFilter) (*pb.TaskList, error) {
+	filter, err := aclFilter(ctx, readAccess)
+	if err != nil {
+		return nil, err
+	}
+	if req.AssigneeId != "" {
+		assignee, err := resolveUser(ctx, req.AssigneeId)
+		if err != nil {
+			return nil, err
+		}
+		filter["assigneeids"] = assignee
+	}
+	if req.WatcherId != "" {
+		watcher, err := resolveUser(ctx, req.WatcherId)
+		if err != nil {
+			return nil, err
+		}
+		filter["watcherids"] = watcher
+	}
+	if req.ProjectId != "" {
+		filter["projectid"] = req.ProjectId
+	}
+	if req.MilestoneId != "" {
+		filter["milestoneid"] = req.MilestoneId
+	}
+	cursor, err := s.mongoCol.Find(ctx, filter)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list tasks: %v", err)
+	}
 	defer func() {
 		if err := cursor.Close(ctx); err != nil {
-			log.Printf("failed to close cursor: %v", err)
+			slog.ErrorContext(ctx, "failed to close cursor", "error", err)
 		}
 	}()
 	var tasks []*pb.Task
@@ -69,47 +171,164 @@ func (s *server) GetTasks(ctx context.Context, _ *pb.Empty) (*pb.TaskList, error
 		var t pb.Task
 		if err := cursor.Decode(&t); err != nil {
 			// Log the error but continue processing other tasks
-			log.Printf("failed to decode task: %v", err)
-		}else{
-			tasks = append(tasks, &t)
+			slog.ErrorContext(ctx, "failed to decode task", "error", err)
+		} else {
+			tasks = append(tasks, computeFields(&t))
 		}
 	}
 	// Always return a TaskList, possibly empty
 	return &pb.TaskList{Tasks: tasks}, nil
 }
 
-// update task implementing upsert behavior, i.e., 
+// editableFields returns the task fields clients may set through UpdateTask.
+// Fields managed by dedicated RPCs (e.g. attachments, checklist, worklogs) are left untouched.
+func editableFields(req *pb.Task) bson.M {
+	return bson.M{
+		"title":                   req.Title,
+		"description":             req.Description,
+		"completed":               req.Completed,
+		"completeonchecklistdone": req.CompleteOnChecklistDone,
+		"duedate":                 req.DueDate,
+		"recurrence":              req.Recurrence,
+		"parentid":                req.ParentId,
+		"labels":                  req.Labels,
+		"estimateseconds":         req.EstimateSeconds,
+		"projectid":               req.ProjectId,
+		"status":                  req.Status,
+		"milestoneid":             req.MilestoneId,
+		"storypoints":             req.StoryPoints,
+	}
+}
+
+// computeFields fills in the task fields derived from its stored data.
+func computeFields(task *pb.Task) *pb.Task {
+	task.ChecklistProgress = checklistProgress(task.Checklist)
+	task.LoggedSeconds = loggedSeconds(task.Worklogs)
+	return task
+}
+
+// update task implementing upsert behavior, i.e.,
 // create a new task with that ID if it does not exist, or update it if it does
 func (s *server) UpdateTask(ctx context.Context, req *pb.Task) (*pb.Task, error) {
-    filter := bson.M{"id": req.Id}
-    update := bson.M{"$set": req}
-    opts := options.Update().SetUpsert(true)  // Enable upsert behavior, i.e., create if not exists
-    _, err := s.mongoCol.UpdateOne(ctx, filter, update, opts)
-	if err != nil {
-        return nil, status.Errorf(codes.Internal, "failed to update task: %v", err)
-    }
-    return req, nil
+	filter, err := taskFilter(ctx, req.Id, writeAccess)
+	if err != nil {
+		return nil, err
+	}
+	owner, err := resolveUser(ctx, "me")
+	if err != nil {
+		return nil, err
+	}
+	if req.ProjectId != "" {
+		if err := requireProjectExists(ctx, s.projectsCol, req.ProjectId); err != nil {
+			return nil, err
+		}
+	}
+	if req.MilestoneId != "" {
+		if err := requireMilestoneExists(ctx, s.milestonesCol, req.MilestoneId); err != nil {
+			return nil, err
+		}
+	}
+	update := bson.M{
+		"$set": editableFields(req),
+		// a task created by the upsert is owned by the caller
+		"$setOnInsert": bson.M{"acl": &pb.TaskACL{OwnerId: owner}},
+	}
+	// Enable upsert behavior, i.e., create if not exists, and return the stored task
+	// unless the workspace can't hold another task
+	full, err := s.atTaskQuota(ctx, 1)
+	if err != nil {
+		return nil, err
+	}
+	opts := options.FindOneAndUpdate().SetUpsert(!full).SetReturnDocument(options.After)
+	var updated pb.Task
+	err = s.mongoCol.FindOneAndUpdate(ctx, filter, update, opts).Decode(&updated)
+	if full && errors.Is(err, mongo.ErrNoDocuments) {
+		if err := s.accessError(ctx, req.Id, writeAccess); status.Code(err) != codes.NotFound {
+			return nil, err
+		}
+		return nil, s.taskQuotaError(ctx)
+	}
+	// the task exists, but the caller may not write it, so the upsert collided with its unique ID
+	if mongo.IsDuplicateKeyError(err) {
+		return nil, s.accessError(ctx, req.Id, writeAccess)
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to update task: %v", err)
+	}
+	s.recordHistory(ctx, &updated, false)
+	// completing a recurring task materializes its next occurrence
+	if err := s.materializeNextOccurrence(ctx, &updated); err != nil {
+		slog.ErrorContext(ctx, "failed to create next occurrence", "task_id", updated.Id, "error", err)
+	}
+	return computeFields(&updated), nil
 }
 
 // DeleteTask deletes a task by its ID from the MongoDB collection.
 // It returns the deleted task if found, or an error if not found.
 func (s *server) DeleteTask(ctx context.Context, req *pb.TaskID) (*pb.Task, error) {
-    var deletedTask pb.Task
-    err := s.mongoCol.FindOne(ctx, bson.M{"id": req.Id}).Decode(&deletedTask)
-    if err != nil {
-        return nil, status.Errorf(codes.NotFound, "task with id %s not found", req.Id)
-    }
-    _, err = s.mongoCol.DeleteOne(ctx, bson.M{"id": req.Id})
-    if err != nil {
-        return nil, status.Errorf(codes.Internal, "failed to delete task: %v", err)
-    }
-    return &deletedTask, nil
+	filter, err := taskFilter(ctx, req.Id, writeAccess)
+	if err != nil {
+		return nil, err
+	}
+	var deletedTask pb.Task
+	err = s.mongoCol.FindOne(ctx, filter).Decode(&deletedTask)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, s.accessError(ctx, req.Id, writeAccess)
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get task: %v", err)
+	}
+	_, err = s.mongoCol.DeleteOne(ctx, bson.M{"id": req.Id})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to delete task: %v", err)
+	}
+	s.deleteAttachmentBlobs(ctx, req.Id, deletedTask.Attachments, "")
+	s.recordHistory(ctx, &deletedTask, true)
+	return computeFields(&deletedTask), nil
 }
 
+// newBlobStore creates the attachment blob store selected by the BLOB_STORE environment variable.
+func newBlobStore() (blobstore.BlobStore, error) {
+	switch kind := config.GetEnv("BLOB_STORE", "local"); kind {
+	case "local":
+		return blobstore.NewLocalStore(config.GetEnv("ATTACHMENT_DIR", "/data/attachments"))
+	default:
+		return nil, fmt.Errorf("unsupported BLOB_STORE %q", kind)
+	}
+}
+
+// serverCredentials returns the server option requiring mutual TLS when TLS_CERT, TLS_KEY and TLS_CA name
+// the backend's certificate, its key and the CA of its clients' certificates, or nil to serve plaintext.
+// Clients must present a certificate with one of the SANs listed by TLS_CLIENT_SANS, if set.
+func serverCredentials() (grpc.ServerOption, error) {
+	cfg := mtls.Config{
+		CertFile:       config.GetEnv("TLS_CERT", ""),
+		KeyFile:        config.GetEnv("TLS_KEY", ""),
+		CAFile:         config.GetEnv("TLS_CA", ""),
+		AllowedSANs:    config.GetEnvList("TLS_CLIENT_SANS", nil),
+		ReloadInterval: time.Duration(config.GetEnvInt64("TLS_RELOAD_SECONDS", 30)) * time.Second,
+	}
+	if !cfg.Enabled() {
+		return nil, nil
+	}
+	certs, err := mtls.NewReloader(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return grpc.Creds(credentials.NewTLS(certs.ServerConfig())), nil
+}
 
 func main() {
 	// loads .env for local debugging
-	debug:=config.LoadDotenvIfDebug()
+	debug := config.LoadDotenvIfDebug()
+	// log JSON lines, the standard logger included
+	if err := logging.Setup("task-backend"); err != nil {
+		log.Fatal(err)
+	}
+	// LOG_LEVEL_FILE, such as a mounted ConfigMap key, sets the level of every replica at runtime
+	if path := config.GetEnv("LOG_LEVEL_FILE", ""); path != "" {
+		go logging.WatchLevelFile(context.Background(), path, time.Duration(config.GetEnvInt64("LOG_LEVEL_FILE_SECONDS", 10))*time.Second)
+	}
 
 	mongoUser, okUser := os.LookupEnv("MONGO_USERNAME")
 	mongoPass, okPass := os.LookupEnv("MONGO_PASSWORD")
@@ -120,36 +339,160 @@ func main() {
 	if !okPass || mongoPass == "" {
 		log.Fatal("Environment variable MONGO_PASSWORD is not set")
 	}
+	// shared with the API, the only caller allowed besides health probes
+	serviceSecret, okSecret := os.LookupEnv("SERVICE_SECRET")
+	if !okSecret || serviceSecret == "" {
+		log.Fatal("Environment variable SERVICE_SECRET is not set")
+	}
 
 	mongoHost := "mongodb" // default for Kubernetes
 	// replace host with localhost for local debugging
-	if debug{
+	if debug {
 		mongoHost = "localhost"
 	}
 	mongoURI := fmt.Sprintf("mongodb://%s:%s@%s:27017", mongoUser, mongoPass, mongoHost)
+	shutdownTracing, err := tracing.Setup(context.Background(), "task-backend")
+	if err != nil {
+		log.Fatal(err)
+	}
 	// Connect to MongoDB using the provided URI
-	log.Printf("Connecting to MongoDB at %s", mongoURI)
-	client, err := mongo.Connect(context.Background(), options.Client().ApplyURI(mongoURI))
+	log.Printf("Connecting to MongoDB at %s", logging.RedactURI(mongoURI))
+	client, err := mongo.Connect(context.Background(), options.Client().ApplyURI(mongoURI).
+		SetMonitor(tracing.MongoMonitor(metrics.MongoCommandMonitor())).
+		SetPoolMonitor(metrics.MongoPoolMonitor()))
+	if err != nil {
+		log.Fatal(err)
+	}
+	db := client.Database("tasks")
+	// Use/create the "tasks" collection in the "tasks" database, shared by all workspaces.
+	// task IDs are unique within a workspace, keeping UpdateTask's upsert from duplicating a task the caller may not write
+	col, err := newScopedCollection(context.Background(), db.Collection("tasks"), "id")
+	if err != nil {
+		log.Fatal(err)
+	}
+	users, err := newUserServer(context.Background(), db.Collection("users"), db.Collection("teams"), db.Collection("workspaces"))
+	if err != nil {
+		log.Fatal(err)
+	}
+	keys, err := newAuthServer(context.Background(), db.Collection("apikeys"), users.mongoCol, users.teamsCol)
+	if err != nil {
+		log.Fatal(err)
+	}
+	rateLimits, err := newRateLimitServer(context.Background(), db.Collection("ratelimits"))
 	if err != nil {
 		log.Fatal(err)
 	}
-	col := client.Database("tasks").Collection("tasks") // Use/create the "tasks" collection in the "tasks" database
 
-	// creates a TCP network listener on port 50051 for gRPC server 
+	blobs, err := newBlobStore()
+	if err != nil {
+		log.Fatal(err)
+	}
+	projectsCol, err := newScopedCollection(context.Background(), db.Collection("projects"), "id")
+	if err != nil {
+		log.Fatal(err)
+	}
+	milestonesCol, err := newScopedCollection(context.Background(), db.Collection("milestones"), "id")
+	if err != nil {
+		log.Fatal(err)
+	}
+	historyCol, err := newHistoryCollection(context.Background(), db.Collection("taskhistory"))
+	if err != nil {
+		log.Fatal(err)
+	}
+	tasks := &server{
+		mongoCol:           col,
+		usersCol:           users.mongoCol,
+		teamsCol:           users.teamsCol,
+		projectsCol:        projectsCol,
+		milestonesCol:      milestonesCol,
+		historyCol:         historyCol,
+		blobs:              blobs,
+		maxAttachmentBytes: config.GetEnvInt64("ATTACHMENT_MAX_BYTES", 10<<20),
+		maxTasks:           config.GetEnvInt64("MAX_TASKS_PER_WORKSPACE", 10000),
+	}
+	projects, err := newProjectServer(context.Background(), projectsCol, db.Collection("boards"), tasks)
+	if err != nil {
+		log.Fatal(err)
+	}
+	templates, err := newTemplateServer(context.Background(), db.Collection("templates"), tasks)
+	if err != nil {
+		log.Fatal(err)
+	}
+	// a workspace is only deleted once its tenant's data is removed, its task history going with it
+	workspaces, err := newWorkspaceServer(context.Background(), db.Collection("workspaces"),
+		[]*scopedCollection{users.mongoCol, users.teamsCol, keys.mongoCol, col, projectsCol, milestonesCol, templates.mongoCol},
+		[]*scopedCollection{historyCol, projects.boardsCol})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// creates a TCP network listener on port 50051 for gRPC server
 	lis, err := net.Listen("tcp", ":50051")
 	if err != nil {
 		log.Fatal(err)
 	}
 
-	// register server as a gRPC TaskServiceServer 
-	grpcServer := grpc.NewServer()
-	pb.RegisterTaskServiceServer(grpcServer, &server{mongoCol: col})
+	// register server as a gRPC TaskServiceServer
+	// connections are closed after MAX_CONNECTION_AGE_SECONDS, so clients resolve the replicas again
+	// interceptors run in order: metrics and logs cover rejected calls too, only calls signed by the API
+	// are let through, to be shed over the concurrency limit before the role policy is enforced
+	opts := []grpc.ServerOption{
+		grpc.KeepaliveParams(keepalive.ServerParameters{
+			MaxConnectionAge:      time.Duration(config.GetEnvInt64("MAX_CONNECTION_AGE_SECONDS", 300)) * time.Second,
+			MaxConnectionAgeGrace: time.Duration(config.GetEnvInt64("MAX_CONNECTION_AGE_GRACE_SECONDS", 30)) * time.Second,
+		}),
+		grpc.KeepaliveEnforcementPolicy(keepalive.EnforcementPolicy{MinTime: 10 * time.Second, PermitWithoutStream: true}),
+		grpc.StatsHandler(tracing.ServerHandler()),
+		grpc.ChainUnaryInterceptor(metrics.UnaryServerInterceptor(), logging.UnaryServerInterceptor(), deadlineUnaryInterceptor(), svcauth.UnaryServerInterceptor(serviceSecret), newLoadShedder().UnaryServerInterceptor(), rbac.UnaryServerInterceptor()),
+		grpc.ChainStreamInterceptor(metrics.StreamServerInterceptor(), logging.StreamServerInterceptor(), deadlineStreamInterceptor(), svcauth.StreamServerInterceptor(serviceSecret), rbac.StreamServerInterceptor()),
+	}
+	creds, err := serverCredentials()
+	if err != nil {
+		log.Fatal(err)
+	}
+	if creds != nil {
+		opts = append(opts, creds)
+	}
+	grpcServer := grpc.NewServer(opts...)
+	pb.RegisterTaskServiceServer(grpcServer, tasks)
+	pb.RegisterProjectServiceServer(grpcServer, projects)
+	pb.RegisterMilestoneServiceServer(grpcServer, &milestoneServer{mongoCol: milestonesCol, tasks: tasks})
+	pb.RegisterTemplateServiceServer(grpcServer, templates)
+	pb.RegisterUserServiceServer(grpcServer, users)
+	pb.RegisterAuthServiceServer(grpcServer, keys)
+	pb.RegisterWorkspaceServiceServer(grpcServer, workspaces)
+	pb.RegisterAdminServiceServer(grpcServer, &adminServer{})
+	pb.RegisterRateLimitServiceServer(grpcServer, rateLimits)
 
 	// Register gRPC health check service for k8 readiness and liveness probes
 	// This allows Kubernetes HPA to check the health of the gRPC server.
+	// Readiness follows MongoDB's reachability, checked every HEALTH_CHECK_SECONDS, while liveness doesn't.
 	healthServer := health.NewServer()
 	healthpb.RegisterHealthServer(grpcServer, healthServer)
+	healthServer.SetServingStatus(livenessService, healthpb.HealthCheckResponse_SERVING)
+	healthServer.SetServingStatus(mongoService, healthpb.HealthCheckResponse_SERVING)
 	healthServer.SetServingStatus("", healthpb.HealthCheckResponse_SERVING)
+	watchCtx, stopWatching := context.WithCancel(context.Background())
+	defer stopWatching()
+	go watchMongo(watchCtx, client, healthServer,
+		time.Duration(config.GetEnvInt64("HEALTH_CHECK_SECONDS", 5))*time.Second,
+		time.Duration(config.GetEnvInt64("HEALTH_CHECK_TIMEOUT_SECONDS", 2))*time.Second)
+	// kubelet's gRPC probes can't present a client certificate, so with mutual TLS the health
+	// service is also served in plaintext on its own port, without any other service
+	var healthOnly *grpc.Server
+	if creds != nil {
+		healthLis, err := net.Listen("tcp", ":"+config.GetEnv("HEALTH_PORT", "50052"))
+		if err != nil {
+			log.Fatal(err)
+		}
+		healthOnly = grpc.NewServer()
+		healthpb.RegisterHealthServer(healthOnly, healthServer)
+		go func() {
+			if err := healthOnly.Serve(healthLis); err != nil {
+				log.Fatalf("failed to serve health checks: %v", err)
+			}
+		}()
+	}
 
 	// Start the gRPC backend server
 	go func() {
@@ -157,26 +500,62 @@ func main() {
 		if err := grpcServer.Serve(lis); err != nil {
 			log.Fatalf("failed to serve: %v", err)
 		}
-		}()
-		
+	}()
+
+	// serve Prometheus metrics on their own port, kept off the gRPC port and the API
+	prometheus.MustRegister(&taskCountCollector{
+		tasks: col.Unscoped(),
+		ttl:   time.Duration(config.GetEnvInt64("METRICS_TASK_COUNT_SECONDS", 60)) * time.Second,
+	})
+	metricsMux := http.NewServeMux()
+	metricsMux.Handle("/metrics", metrics.Handler())
+	metricsServer := &http.Server{Addr: ":" + config.GetEnv("METRICS_PORT", "9090"), Handler: metricsMux}
+	go func() {
+		if err := metricsServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			log.Fatalf("failed to serve metrics: %v", err)
+		}
+	}()
+
 	// Wait for a termination signal (SIGINT or SIGTERM) to gracefully shut down the server
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
 	log.Println("Shutting down gRPC backend...")
 
+	// report NOT_SERVING first and keep serving for DRAIN_SECONDS, until clients stop sending calls here
+	stopWatching()
+	healthServer.Shutdown()
+	time.Sleep(time.Duration(config.GetEnvInt64("DRAIN_SECONDS", 5)) * time.Second)
+
 	done := make(chan struct{})
 	go func() {
 		grpcServer.GracefulStop()
 		close(done)
 	}()
-	
-	// Wait for the server to stop gracefully or timeout after 10 seconds
+
+	// Wait for the server to stop gracefully or timeout after SHUTDOWN_TIMEOUT_SECONDS
+	shutdownTimeout := time.Duration(config.GetEnvInt64("SHUTDOWN_TIMEOUT_SECONDS", 10)) * time.Second
 	select {
 	case <-done:
 		log.Println("gRPC backend exited gracefully")
-	case <-time.After(10 * time.Second):
+	case <-time.After(shutdownTimeout):
 		log.Println("gRPC backend forced to stop")
 		grpcServer.Stop()
 	}
+	if healthOnly != nil {
+		healthOnly.Stop()
+	}
+	metricsServer.Close()
+
+	// no call is in flight anymore, so MongoDB's connections can be closed
+	disconnectCtx, cancelDisconnect := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancelDisconnect()
+	if err := client.Disconnect(disconnectCtx); err != nil {
+		log.Printf("failed to disconnect from MongoDB: %v", err)
+	}
+	flushCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := shutdownTracing(flushCtx); err != nil {
+		log.Printf("failed to flush traces: %v", err)
+	}
 }
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/Cohen-J-Omer/k8-task-mgmt-system/taskmgmt/internal/metrics"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// taskCount is the number of tasks of a workspace with a given status and completion.
+type taskCount struct {
+	Key struct {
+		Workspace string `bson:"workspace"`
+		Status    string `bson:"status"`
+		Completed bool   `bson:"completed"`
+	} `bson:"_id"`
+	Count int64 `bson:"count"`
+}
+
+// taskCountCollector exports metrics.TasksDesc. Tasks are counted across all workspaces when scraped,
+// at most once per ttl, so frequent scrapes don't load MongoDB.
+type taskCountCollector struct {
+	tasks *mongo.Collection
+	ttl   time.Duration
+
+	mu      sync.Mutex
+	counts  []taskCount
+	counted time.Time
+}
+
+func (c *taskCountCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- metrics.TasksDesc
+}
+
+func (c *taskCountCollector) Collect(ch chan<- prometheus.Metric) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if time.Since(c.counted) >= c.ttl {
+		// on failure the last counts keep being exported
+		if counts, err := c.count(); err != nil {
+			slog.Error("failed to count tasks for metrics", "error", err)
+		} else {
+			c.counts = counts
+		}
+		c.counted = time.Now()
+	}
+	for _, tc := range c.counts {
+		ch <- prometheus.MustNewConstMetric(metrics.TasksDesc, prometheus.GaugeValue, float64(tc.Count),
+			tc.Key.Workspace, tc.Key.Status, strconv.FormatBool(tc.Key.Completed))
+	}
+}
+
+// count groups the tasks of all workspaces by status and completion.
+func (c *taskCountCollector) count() ([]taskCount, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	cursor, err := c.tasks.Aggregate(ctx, mongo.Pipeline{
+		{{Key: "$group", Value: bson.M{
+			"_id": bson.M{
+				"workspace": "$" + workspaceField,
+				"status":    bson.M{"$ifNull": bson.A{"$status", ""}},
+				"completed": bson.M{"$ifNull": bson.A{"$completed", false}},
+			},
+			"count": bson.M{"$sum": 1},
+		}}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	var counts []taskCount
+	if err := cursor.All(ctx, &counts); err != nil {
+		return nil, err
+	}
+	return counts, nil
+}
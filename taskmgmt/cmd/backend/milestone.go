@@ -0,0 +1,236 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/Cohen-J-Omer/k8-task-mgmt-system/taskmgmt/internal/validator"
+	pb "github.com/Cohen-J-Omer/k8-task-mgmt-system/taskmgmt/proto"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// implements gRPC's MilestoneServiceServer interface
+// milestoneServer manages milestones, such as sprints, and the burndown charts of their tasks.
+type milestoneServer struct {
+	pb.UnimplementedMilestoneServiceServer
+	mongoCol *scopedCollection // collection handler for the "milestones" MongoDB collection
+	tasks    *server           // the task service, whose history the burndown charts are computed from
+}
+
+// historyEvent records the state of a task relevant to burndown charts, whenever it changes.
+// A deleted task is recorded as leaving its milestone.
+type historyEvent struct {
+	ID          string    `bson:"id"`
+	TaskID      string    `bson:"taskid"`
+	At          time.Time `bson:"at"`
+	MilestoneID string    `bson:"milestoneid"`
+	Completed   bool      `bson:"completed"`
+	Points      int32     `bson:"points"`
+}
+
+// newHistoryCollection scopes the "taskhistory" collection, indexing the events of each task by time.
+func newHistoryCollection(ctx context.Context, col *mongo.Collection) (*scopedCollection, error) {
+	scoped, err := newScopedCollection(ctx, col, "id")
+	if err != nil {
+		return nil, err
+	}
+	_, err = col.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: workspaceField, Value: 1}, {Key: "taskid", Value: 1}, {Key: "at", Value: 1}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return scoped, nil
+}
+
+// requireMilestoneExists fails with InvalidArgument unless the milestone exists.
+func requireMilestoneExists(ctx context.Context, milestones *scopedCollection, id string) error {
+	if err := milestones.FindOne(ctx, bson.M{"id": id}).Err(); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return status.Errorf(codes.InvalidArgument, "unknown milestone: %s", id)
+		}
+		return status.Errorf(codes.Internal, "failed to look up milestone: %v", err)
+	}
+	return nil
+}
+
+// recordHistory appends the task's state to its history if it changed since the last recorded event.
+// Tasks that never belonged to a milestone aren't recorded. Failures are logged rather than failing
+// the request that changed the task, at worst leaving a gap in a burndown chart.
+func (s *server) recordHistory(ctx context.Context, task *pb.Task, deleted bool) {
+	event := historyEvent{
+		ID:          uuid.New().String(),
+		TaskID:      task.Id,
+		At:          time.Now().UTC(),
+		MilestoneID: task.MilestoneId,
+		Completed:   task.Completed,
+		Points:      task.StoryPoints,
+	}
+	if deleted {
+		event.MilestoneID = ""
+	}
+	var last historyEvent
+	opts := options.FindOne().SetSort(bson.D{{Key: "at", Value: -1}, {Key: "_id", Value: -1}})
+	err := s.historyCol.FindOne(ctx, bson.M{"taskid": task.Id}, opts).Decode(&last)
+	switch {
+	case errors.Is(err, mongo.ErrNoDocuments):
+		if event.MilestoneID == "" {
+			return
+		}
+	case err != nil:
+		slog.ErrorContext(ctx, "failed to record task history", "task_id", task.Id, "error", err)
+		return
+	case last.MilestoneID == event.MilestoneID && last.Completed == event.Completed && last.Points == event.Points:
+		return
+	}
+	if _, err := s.historyCol.InsertOne(ctx, event); err != nil {
+		slog.ErrorContext(ctx, "failed to record task history", "task_id", task.Id, "error", err)
+	}
+}
+
+// CreateMilestone stores a new milestone.
+func (s *milestoneServer) CreateMilestone(ctx context.Context, req *pb.Milestone) (*pb.Milestone, error) {
+	if err := validator.ValidateMilestone(req); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	req.Id = uuid.New().String()
+	if _, err := s.mongoCol.InsertOne(ctx, req); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to create milestone: %v", err)
+	}
+	return req, nil
+}
+
+// GetMilestone retrieves a milestone by its ID.
+func (s *milestoneServer) GetMilestone(ctx context.Context, req *pb.MilestoneID) (*pb.Milestone, error) {
+	var milestone pb.Milestone
+	if err := s.mongoCol.FindOne(ctx, bson.M{"id": req.Id}).Decode(&milestone); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, status.Errorf(codes.NotFound, "milestone %s not found", req.Id)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get milestone: %v", err)
+	}
+	return &milestone, nil
+}
+
+// GetMilestones retrieves all milestones ordered by start date.
+func (s *milestoneServer) GetMilestones(ctx context.Context, _ *pb.Empty) (*pb.MilestoneList, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "startdate", Value: 1}, {Key: "name", Value: 1}})
+	cursor, err := s.mongoCol.Find(ctx, bson.M{}, opts)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list milestones: %v", err)
+	}
+	list := &pb.MilestoneList{Milestones: []*pb.Milestone{}}
+	if err := cursor.All(ctx, &list.Milestones); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list milestones: %v", err)
+	}
+	return list, nil
+}
+
+// UpdateMilestone replaces a milestone's name and dates.
+func (s *milestoneServer) UpdateMilestone(ctx context.Context, req *pb.Milestone) (*pb.Milestone, error) {
+	if err := validator.ValidateMilestone(req); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	var milestone pb.Milestone
+	update := bson.M{"$set": bson.M{"name": req.Name, "startdate": req.StartDate, "enddate": req.EndDate}}
+	err := s.mongoCol.FindOneAndUpdate(ctx, bson.M{"id": req.Id}, update, options.FindOneAndUpdate().SetReturnDocument(options.After)).Decode(&milestone)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, status.Errorf(codes.NotFound, "milestone %s not found", req.Id)
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to update milestone: %v", err)
+	}
+	return &milestone, nil
+}
+
+// DeleteMilestone deletes a milestone that no task belongs to anymore.
+func (s *milestoneServer) DeleteMilestone(ctx context.Context, req *pb.MilestoneID) (*pb.Milestone, error) {
+	n, err := s.tasks.mongoCol.CountDocuments(ctx, bson.M{"milestoneid": req.Id}, options.Count().SetLimit(1))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to delete milestone: %v", err)
+	}
+	if n > 0 {
+		return nil, status.Errorf(codes.FailedPrecondition, "milestone %s still has tasks", req.Id)
+	}
+	var milestone pb.Milestone
+	if err := s.mongoCol.FindOneAndDelete(ctx, bson.M{"id": req.Id}).Decode(&milestone); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, status.Errorf(codes.NotFound, "milestone %s not found", req.Id)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to delete milestone: %v", err)
+	}
+	return &milestone, nil
+}
+
+// GetBurndown replays the history of the tasks that ever belonged to the milestone, reporting the
+// tasks and points in the milestone and those still open at the end of each of its days so far.
+// Like WIP limits, the chart counts all of the milestone's tasks, including those the caller may not see.
+func (s *milestoneServer) GetBurndown(ctx context.Context, req *pb.MilestoneID) (*pb.Burndown, error) {
+	milestone, err := s.GetMilestone(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	// both dates were validated when the milestone was stored
+	start, _ := time.Parse(time.DateOnly, milestone.StartDate)
+	end, _ := time.Parse(time.DateOnly, milestone.EndDate)
+
+	cursor, err := s.tasks.historyCol.Aggregate(ctx, mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"milestoneid": req.Id}}},
+		{{Key: "$group", Value: bson.M{"_id": "$taskid"}}},
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get burndown: %v", err)
+	}
+	var groups []struct {
+		TaskID string `bson:"_id"`
+	}
+	if err := cursor.All(ctx, &groups); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get burndown: %v", err)
+	}
+	taskIDs := make([]string, len(groups))
+	for i, g := range groups {
+		taskIDs[i] = g.TaskID
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "at", Value: 1}, {Key: "_id", Value: 1}})
+	cursor, err = s.tasks.historyCol.Find(ctx, bson.M{"taskid": bson.M{"$in": taskIDs}}, opts)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get burndown: %v", err)
+	}
+	var events []historyEvent
+	if err := cursor.All(ctx, &events); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get burndown: %v", err)
+	}
+
+	burndown := &pb.Burndown{Milestone: milestone, Days: []*pb.BurndownDay{}}
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	latest := make(map[string]historyEvent) // the state of each task at the end of the current day
+	next := 0
+	for day := start; !day.After(end) && !day.After(today); day = day.AddDate(0, 0, 1) {
+		cutoff := day.AddDate(0, 0, 1)
+		for ; next < len(events) && events[next].At.Before(cutoff); next++ {
+			latest[events[next].TaskID] = events[next]
+		}
+		point := &pb.BurndownDay{Date: day.Format(time.DateOnly)}
+		for _, state := range latest {
+			if state.MilestoneID != req.Id {
+				continue
+			}
+			point.ScopeTasks++
+			point.ScopePoints += state.Points
+			if !state.Completed {
+				point.RemainingTasks++
+				point.RemainingPoints += state.Points
+			}
+		}
+		burndown.Days = append(burndown.Days, point)
+	}
+	return burndown, nil
+}
@@ -0,0 +1,446 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"time"
+
+	"github.com/Cohen-J-Omer/k8-task-mgmt-system/taskmgmt/internal/rank"
+	"github.com/Cohen-J-Omer/k8-task-mgmt-system/taskmgmt/internal/validator"
+	pb "github.com/Cohen-J-Omer/k8-task-mgmt-system/taskmgmt/proto"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// implements gRPC's ProjectServiceServer interface
+// projectServer manages projects and the Kanban boards showing their tasks.
+type projectServer struct {
+	pb.UnimplementedProjectServiceServer
+	mongoCol  *scopedCollection // collection handler for the "projects" MongoDB collection
+	boardsCol *scopedCollection // collection handler for the "boards" MongoDB collection
+	tasks     *server           // the task service, whose access checks apply to the boards' cards
+}
+
+// newProjectServer creates a projectServer, ensuring board IDs are unique within each workspace.
+func newProjectServer(ctx context.Context, projects *scopedCollection, boards *mongo.Collection, tasks *server) (*projectServer, error) {
+	scoped, err := newScopedCollection(ctx, boards, "id")
+	if err != nil {
+		return nil, err
+	}
+	return &projectServer{mongoCol: projects, boardsCol: scoped, tasks: tasks}, nil
+}
+
+// requireProjectExists fails with InvalidArgument unless the project exists.
+func requireProjectExists(ctx context.Context, projects *scopedCollection, id string) error {
+	if err := projects.FindOne(ctx, bson.M{"id": id}).Err(); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return status.Errorf(codes.InvalidArgument, "unknown project: %s", id)
+		}
+		return status.Errorf(codes.Internal, "failed to look up project: %v", err)
+	}
+	return nil
+}
+
+// CreateProject stores a new project.
+func (s *projectServer) CreateProject(ctx context.Context, req *pb.Project) (*pb.Project, error) {
+	if err := validator.ValidateProject(req); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	req.Id = uuid.New().String()
+	req.CreatedAt = time.Now().UTC().Format(time.RFC3339)
+	if _, err := s.mongoCol.InsertOne(ctx, req); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to create project: %v", err)
+	}
+	return req, nil
+}
+
+// GetProject retrieves a project by its ID.
+func (s *projectServer) GetProject(ctx context.Context, req *pb.ProjectID) (*pb.Project, error) {
+	var project pb.Project
+	if err := s.mongoCol.FindOne(ctx, bson.M{"id": req.Id}).Decode(&project); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, status.Errorf(codes.NotFound, "project %s not found", req.Id)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get project: %v", err)
+	}
+	return &project, nil
+}
+
+// GetProjects retrieves all projects ordered by name.
+func (s *projectServer) GetProjects(ctx context.Context, _ *pb.Empty) (*pb.ProjectList, error) {
+	cursor, err := s.mongoCol.Find(ctx, bson.M{}, options.Find().SetSort(bson.D{{Key: "name", Value: 1}}))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list projects: %v", err)
+	}
+	list := &pb.ProjectList{Projects: []*pb.Project{}}
+	if err := cursor.All(ctx, &list.Projects); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list projects: %v", err)
+	}
+	return list, nil
+}
+
+// UpdateProject replaces a project's name and description.
+func (s *projectServer) UpdateProject(ctx context.Context, req *pb.Project) (*pb.Project, error) {
+	if err := validator.ValidateProject(req); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	var project pb.Project
+	update := bson.M{"$set": bson.M{"name": req.Name, "description": req.Description}}
+	err := s.mongoCol.FindOneAndUpdate(ctx, bson.M{"id": req.Id}, update, options.FindOneAndUpdate().SetReturnDocument(options.After)).Decode(&project)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, status.Errorf(codes.NotFound, "project %s not found", req.Id)
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to update project: %v", err)
+	}
+	return &project, nil
+}
+
+// DeleteProject deletes a project that no task belongs to anymore, along with its boards.
+func (s *projectServer) DeleteProject(ctx context.Context, req *pb.ProjectID) (*pb.Project, error) {
+	n, err := s.tasks.mongoCol.CountDocuments(ctx, bson.M{"projectid": req.Id}, options.Count().SetLimit(1))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to delete project: %v", err)
+	}
+	if n > 0 {
+		return nil, status.Errorf(codes.FailedPrecondition, "project %s still has tasks", req.Id)
+	}
+	var project pb.Project
+	if err := s.mongoCol.FindOneAndDelete(ctx, bson.M{"id": req.Id}).Decode(&project); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, status.Errorf(codes.NotFound, "project %s not found", req.Id)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to delete project: %v", err)
+	}
+	if _, err := s.boardsCol.DeleteMany(ctx, bson.M{"projectid": req.Id}); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to delete the project's boards: %v", err)
+	}
+	return &project, nil
+}
+
+// CreateBoard stores a new board of an existing project.
+func (s *projectServer) CreateBoard(ctx context.Context, req *pb.Board) (*pb.Board, error) {
+	if err := validator.ValidateBoard(req); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err := requireProjectExists(ctx, s.mongoCol, req.ProjectId); err != nil {
+		return nil, err
+	}
+	req.Id = uuid.New().String()
+	if _, err := s.boardsCol.InsertOne(ctx, req); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to create board: %v", err)
+	}
+	return req, nil
+}
+
+// findBoard retrieves a board by its ID.
+func (s *projectServer) findBoard(ctx context.Context, id string) (*pb.Board, error) {
+	var board pb.Board
+	if err := s.boardsCol.FindOne(ctx, bson.M{"id": id}).Decode(&board); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, status.Errorf(codes.NotFound, "board %s not found", id)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get board: %v", err)
+	}
+	return &board, nil
+}
+
+// columnFilter matches the tasks of the board's project shown in its i-th column.
+func columnFilter(board *pb.Board, i int) bson.M {
+	statuses := bson.A{}
+	for _, st := range board.Columns[i].Statuses {
+		statuses = append(statuses, st)
+	}
+	// tasks without a status are shown in the first column
+	if i == 0 {
+		statuses = append(statuses, "", nil)
+	}
+	return bson.M{"projectid": board.ProjectId, "status": bson.M{"$in": statuses}}
+}
+
+// inColumn reports whether a task with the given status is shown in the board's i-th column.
+func inColumn(board *pb.Board, i int, taskStatus string) bool {
+	if taskStatus == "" {
+		return i == 0
+	}
+	for _, st := range board.Columns[i].Statuses {
+		if st == taskStatus {
+			return true
+		}
+	}
+	return false
+}
+
+// sortCards orders cards by rank. Cards never moved have no rank and follow the ranked ones.
+func sortCards(cards []*pb.Task) {
+	sort.Slice(cards, func(i, j int) bool {
+		a, b := cards[i], cards[j]
+		if (a.Rank == "") != (b.Rank == "") {
+			return a.Rank != ""
+		}
+		if a.Rank != b.Rank {
+			return a.Rank < b.Rank
+		}
+		return a.Id < b.Id
+	})
+}
+
+// GetBoard retrieves a board with the cards of its columns. Only the tasks the caller may see are
+// shown, while WIP limits apply to all of the column's tasks.
+func (s *projectServer) GetBoard(ctx context.Context, req *pb.BoardID) (*pb.BoardView, error) {
+	board, err := s.findBoard(ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	filter, err := aclFilter(ctx, readAccess)
+	if err != nil {
+		return nil, err
+	}
+	filter["projectid"] = board.ProjectId
+	cursor, err := s.tasks.mongoCol.Find(ctx, filter)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list the board's tasks: %v", err)
+	}
+	var tasks []*pb.Task
+	if err := cursor.All(ctx, &tasks); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list the board's tasks: %v", err)
+	}
+
+	view := &pb.BoardView{Board: board}
+	for _, col := range board.Columns {
+		view.Columns = append(view.Columns, &pb.BoardColumnView{Column: col, Cards: []*pb.Task{}})
+	}
+	for _, task := range tasks {
+		for i, col := range view.Columns {
+			if inColumn(board, i, task.Status) {
+				col.Cards = append(col.Cards, computeFields(task))
+				break
+			}
+		}
+	}
+	for i, col := range view.Columns {
+		sortCards(col.Cards)
+		if limit := col.Column.WipLimit; limit > 0 {
+			n, err := s.tasks.mongoCol.CountDocuments(ctx, columnFilter(board, i))
+			if err != nil {
+				return nil, status.Errorf(codes.Internal, "failed to count the board's tasks: %v", err)
+			}
+			col.OverWipLimit = n > int64(limit)
+		}
+	}
+	return view, nil
+}
+
+// GetBoards retrieves all boards ordered by name, optionally only those of one project.
+func (s *projectServer) GetBoards(ctx context.Context, req *pb.BoardFilter) (*pb.BoardList, error) {
+	filter := bson.M{}
+	if req.ProjectId != "" {
+		filter["projectid"] = req.ProjectId
+	}
+	cursor, err := s.boardsCol.Find(ctx, filter, options.Find().SetSort(bson.D{{Key: "name", Value: 1}}))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list boards: %v", err)
+	}
+	list := &pb.BoardList{Boards: []*pb.Board{}}
+	if err := cursor.All(ctx, &list.Boards); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list boards: %v", err)
+	}
+	return list, nil
+}
+
+// UpdateBoard replaces a board's project, name and columns.
+func (s *projectServer) UpdateBoard(ctx context.Context, req *pb.Board) (*pb.Board, error) {
+	if err := validator.ValidateBoard(req); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err := requireProjectExists(ctx, s.mongoCol, req.ProjectId); err != nil {
+		return nil, err
+	}
+	var board pb.Board
+	update := bson.M{"$set": bson.M{"projectid": req.ProjectId, "name": req.Name, "columns": req.Columns}}
+	err := s.boardsCol.FindOneAndUpdate(ctx, bson.M{"id": req.Id}, update, options.FindOneAndUpdate().SetReturnDocument(options.After)).Decode(&board)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, status.Errorf(codes.NotFound, "board %s not found", req.Id)
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to update board: %v", err)
+	}
+	return &board, nil
+}
+
+// DeleteBoard deletes a board, leaving its project's tasks untouched.
+func (s *projectServer) DeleteBoard(ctx context.Context, req *pb.BoardID) (*pb.Board, error) {
+	var board pb.Board
+	if err := s.boardsCol.FindOneAndDelete(ctx, bson.M{"id": req.Id}).Decode(&board); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, status.Errorf(codes.NotFound, "board %s not found", req.Id)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to delete board: %v", err)
+	}
+	return &board, nil
+}
+
+// MoveCard moves a task's card to a column, above another card or to the bottom, setting the task's
+// status and rank, and completing or reopening it when moved into or out of a done column. The moved
+// card is ranked between its new neighbours, so no other card is renumbered unless concurrent moves
+// ranked cards alike, in which case they're re-ranked in the same bulk write as the move. Moving a card
+// into a full column fails unless forced.
+func (s *projectServer) MoveCard(ctx context.Context, req *pb.MoveCardRequest) (*pb.MoveCardResponse, error) {
+	board, err := s.findBoard(ctx, req.BoardId)
+	if err != nil {
+		return nil, err
+	}
+	target := -1
+	for i, col := range board.Columns {
+		if col.Name == req.Column {
+			target = i
+		}
+	}
+	if target < 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "board %s has no column %q", board.Id, req.Column)
+	}
+	col := board.Columns[target]
+
+	filter, err := taskFilter(ctx, req.TaskId, writeAccess)
+	if err != nil {
+		return nil, err
+	}
+	var task pb.Task
+	if err := s.tasks.mongoCol.FindOne(ctx, filter).Decode(&task); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, s.tasks.accessError(ctx, req.TaskId, writeAccess)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get task: %v", err)
+	}
+	if task.ProjectId != board.ProjectId {
+		return nil, status.Errorf(codes.FailedPrecondition, "task %s does not belong to the board's project", task.Id)
+	}
+
+	// the column's other cards, including those the caller can't see, which count towards the WIP limit
+	others := columnFilter(board, target)
+	others["id"] = bson.M{"$ne": task.Id}
+	cursor, err := s.tasks.mongoCol.Find(ctx, others, options.Find().SetProjection(bson.M{"id": 1, "rank": 1}))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list the column's cards: %v", err)
+	}
+	var cards []*pb.Task
+	if err := cursor.All(ctx, &cards); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list the column's cards: %v", err)
+	}
+	sortCards(cards)
+
+	resp := &pb.MoveCardResponse{}
+	moving := !inColumn(board, target, task.Status)
+	if moving && col.WipLimit > 0 && len(cards) >= int(col.WipLimit) {
+		msg := fmt.Sprintf("column %s has reached its WIP limit of %d", col.Name, col.WipLimit)
+		if !req.Force {
+			return nil, status.Error(codes.FailedPrecondition, msg+", move with force to exceed it")
+		}
+		resp.Warning = msg
+	}
+
+	pos := len(cards)
+	if req.BeforeTaskId != "" {
+		pos = -1
+		for i, card := range cards {
+			if card.Id == req.BeforeTaskId {
+				pos = i
+			}
+		}
+		if pos < 0 {
+			return nil, status.Errorf(codes.InvalidArgument, "task %s is not in column %s", req.BeforeTaskId, col.Name)
+		}
+	}
+	newRank, reranked, err := rankAt(cards, pos)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to rank card: %v", err)
+	}
+
+	set := bson.M{"rank": newRank}
+	if moving {
+		set["status"] = col.Statuses[0]
+		if col.Done || inDoneColumn(board, task.Status) {
+			set["completed"] = col.Done
+		}
+	}
+	models := make([]mongo.WriteModel, 0, len(reranked)+1)
+	for _, card := range reranked {
+		models = append(models, mongo.NewUpdateOneModel().SetFilter(bson.M{"id": card.Id}).SetUpdate(bson.M{"$set": bson.M{"rank": card.Rank}}))
+	}
+	models = append(models, mongo.NewUpdateOneModel().SetFilter(filter).SetUpdate(bson.M{"$set": set}))
+	if _, err := s.tasks.mongoCol.BulkWrite(ctx, models); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to move card: %v", err)
+	}
+	if err := s.tasks.mongoCol.FindOne(ctx, filter).Decode(&task); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, s.tasks.accessError(ctx, req.TaskId, writeAccess)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get task: %v", err)
+	}
+	s.tasks.recordHistory(ctx, &task, false)
+	// completing a recurring task materializes its next occurrence, as through UpdateTask
+	if err := s.tasks.materializeNextOccurrence(ctx, &task); err != nil {
+		slog.ErrorContext(ctx, "failed to create next occurrence", "task_id", task.Id, "error", err)
+	}
+	resp.Task = computeFields(&task)
+	return resp, nil
+}
+
+// inDoneColumn reports whether a task with the given status is shown in one of the board's done columns.
+func inDoneColumn(board *pb.Board, taskStatus string) bool {
+	for i, col := range board.Columns {
+		if col.Done && inColumn(board, i, taskStatus) {
+			return true
+		}
+	}
+	return false
+}
+
+// rankAt returns the rank placing a card at position pos of the sorted cards. Cards never moved follow
+// the ranked ones, and concurrent moves may rank two cards alike: cards above the position without a
+// rank, and cards sharing a rank around it, are re-ranked first and returned so their ranks can be stored.
+func rankAt(cards []*pb.Task, pos int) (string, []*pb.Task, error) {
+	var reranked []*pb.Task
+	prev := ""
+	for i, card := range cards {
+		if i >= pos && (card.Rank == "" || card.Rank > prev) {
+			break
+		}
+		if card.Rank <= prev {
+			r, err := rank.Between(prev, rankAbove(cards[i+1:], prev))
+			if err != nil {
+				return "", nil, err
+			}
+			card.Rank = r
+			reranked = append(reranked, card)
+		}
+		prev = card.Rank
+	}
+	prev, next := "", ""
+	if pos > 0 {
+		prev = cards[pos-1].Rank
+	}
+	if pos < len(cards) {
+		next = cards[pos].Rank
+	}
+	r, err := rank.Between(prev, next)
+	return r, reranked, err
+}
+
+// rankAbove returns the first rank of the sorted cards above r, or "" for the end.
+func rankAbove(cards []*pb.Task, r string) string {
+	for _, card := range cards {
+		if card.Rank > r {
+			return card.Rank
+		}
+	}
+	return ""
+}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// atTaskQuota reports whether the caller's workspace can't hold another n tasks. Requests creating
+// tasks concurrently may still exceed the quota by a few.
+func (s *server) atTaskQuota(ctx context.Context, n int64) (bool, error) {
+	if s.maxTasks <= 0 {
+		return false, nil
+	}
+	count, err := s.mongoCol.CountDocuments(ctx, bson.M{}, options.Count().SetLimit(s.maxTasks))
+	if err != nil {
+		return false, status.Errorf(codes.Internal, "failed to count tasks: %v", err)
+	}
+	return count+n > s.maxTasks, nil
+}
+
+// taskQuotaReason is the ErrorInfo reason of requests creating tasks beyond the workspace's quota.
+const taskQuotaReason = "TASK_QUOTA_EXCEEDED"
+
+// taskQuotaError is the error of requests creating tasks beyond the workspace's quota. It is a
+// FailedPrecondition rather than a ResourceExhausted, which clients take for throttling and retry,
+// as the quota doesn't free up with time.
+func (s *server) taskQuotaError(ctx context.Context) error {
+	workspace, _ := workspaceOf(ctx)
+	st := status.Newf(codes.FailedPrecondition, "workspace %s is limited to %d tasks", workspace, s.maxTasks)
+	if detailed, err := st.WithDetails(&errdetails.ErrorInfo{Reason: taskQuotaReason, Domain: "taskmgmt"}); err == nil {
+		st = detailed
+	}
+	return st.Err()
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"context"
+
+	"github.com/Cohen-J-Omer/k8-task-mgmt-system/taskmgmt/internal/ratelimit"
+	pb "github.com/Cohen-J-Omer/k8-task-mgmt-system/taskmgmt/proto"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// implements gRPC's RateLimitServiceServer interface
+// rateLimitServer keeps the API's token buckets in the "ratelimits" MongoDB collection, shared by all
+// workspaces as the API limits callers before they're known to belong to one.
+type rateLimitServer struct {
+	pb.UnimplementedRateLimitServiceServer
+	mongoCol *mongo.Collection
+}
+
+// rateLimitBucket is a token bucket as of its last update.
+type rateLimitBucket struct {
+	Key     string  `bson:"key"`
+	Tokens  float64 `bson:"tokens"`
+	Allowed bool    `bson:"allowed"` // whether the last update took a token
+}
+
+// newRateLimitServer indexes buckets by key, and expires them once refilled, a full bucket being the
+// same as a missing one.
+func newRateLimitServer(ctx context.Context, col *mongo.Collection) (*rateLimitServer, error) {
+	_, err := col.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "key", Value: 1}}, Options: options.Index().SetUnique(true)},
+		{Keys: bson.D{{Key: "expiresat", Value: 1}}, Options: options.Index().SetExpireAfterSeconds(0)},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &rateLimitServer{mongoCol: col}, nil
+}
+
+// Take refills the key's bucket and takes a token from it if one is left, in a single atomic update
+// timed by MongoDB's clock, so concurrent requests through any API replica are counted exactly.
+func (s *rateLimitServer) Take(ctx context.Context, req *pb.RateLimitRequest) (*pb.RateLimitDecision, error) {
+	if req.Key == "" || req.Rate <= 0 || req.Burst <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "key, rate and burst are required")
+	}
+	burst := float64(req.Burst)
+	elapsed := bson.M{"$divide": bson.A{bson.M{"$subtract": bson.A{"$$NOW", bson.M{"$ifNull": bson.A{"$updatedat", "$$NOW"}}}}, 1000}}
+	refilled := bson.M{"$min": bson.A{burst, bson.M{"$add": bson.A{
+		bson.M{"$ifNull": bson.A{"$tokens", burst}},
+		bson.M{"$multiply": bson.A{elapsed, req.Rate}},
+	}}}}
+	update := mongo.Pipeline{
+		{{Key: "$set", Value: bson.M{"tokens": refilled, "updatedat": "$$NOW"}}},
+		{{Key: "$set", Value: bson.M{"allowed": bson.M{"$gte": bson.A{"$tokens", 1}}}}},
+		{{Key: "$set", Value: bson.M{"tokens": bson.M{"$cond": bson.A{"$allowed", bson.M{"$subtract": bson.A{"$tokens", 1}}, "$tokens"}}}}},
+		// when the bucket will be full again
+		{{Key: "$set", Value: bson.M{"expiresat": bson.M{"$add": bson.A{"$$NOW", bson.M{"$multiply": bson.A{
+			bson.M{"$divide": bson.A{bson.M{"$subtract": bson.A{burst, "$tokens"}}, req.Rate}}, 1000,
+		}}}}}}},
+	}
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+	var bucket rateLimitBucket
+	err := s.mongoCol.FindOneAndUpdate(ctx, bson.M{"key": req.Key}, update, opts).Decode(&bucket)
+	// the first requests of a key raced to create its bucket, which now exists
+	if mongo.IsDuplicateKeyError(err) {
+		err = s.mongoCol.FindOneAndUpdate(ctx, bson.M{"key": req.Key}, update, opts).Decode(&bucket)
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to take a token: %v", err)
+	}
+	d := ratelimit.Decide(bucket.Allowed, bucket.Tokens, ratelimit.Limit{Rate: req.Rate, Burst: int(req.Burst)})
+	return &pb.RateLimitDecision{
+		Allowed:      d.Allowed,
+		Remaining:    int32(d.Remaining),
+		ResetMs:      d.Reset.Milliseconds(),
+		RetryAfterMs: d.RetryAfter.Milliseconds(),
+	}, nil
+}
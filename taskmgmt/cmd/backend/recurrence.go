@@ -0,0 +1,166 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Cohen-J-Omer/k8-task-mgmt-system/taskmgmt/internal/recurrence"
+	pb "github.com/Cohen-J-Omer/k8-task-mgmt-system/taskmgmt/proto"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// maxOccurrences caps the number of occurrences previewed by ListOccurrences.
+	maxOccurrences = 100
+	// maxScannedOccurrences caps the occurrences ListOccurrences skips to reach the requested range.
+	maxScannedOccurrences = 10000
+)
+
+// nextOccurrence returns the due date and number of the occurrence following task,
+// or false when the task is not recurring or its series is exhausted.
+func nextOccurrence(task *pb.Task) (time.Time, int, bool) {
+	if task.Recurrence == "" || task.DueDate == "" {
+		return time.Time{}, 0, false
+	}
+	rule, err := recurrence.Parse(task.Recurrence)
+	if err != nil {
+		return time.Time{}, 0, false
+	}
+	due, err := time.Parse(time.RFC3339, task.DueDate)
+	if err != nil {
+		return time.Time{}, 0, false
+	}
+	it := rule.Iter(due, max(int(task.Occurrence), 1))
+	it.Next() // the task itself
+	return it.Next()
+}
+
+// materializeNextOccurrence creates the next occurrence of a completed recurring task
+// and records its ID on the task. It is a no-op for tasks that are not completed, not
+// recurring, already have a next occurrence, or whose series is exhausted, and fails with
+// FailedPrecondition if the workspace can't hold another task. The next occurrence keeps what
+// describes and plans the work: title, description, checklist items (unchecked), labels, assignees,
+// watchers, ACL, project, estimate and story points. What tracks the completed occurrence's progress
+// is reset: its status and board rank, worklogs, timers, attachments and parent task.
+func (s *server) materializeNextOccurrence(ctx context.Context, task *pb.Task) error {
+	if !task.Completed || task.NextOccurrenceId != "" {
+		return nil
+	}
+	due, n, ok := nextOccurrence(task)
+	if !ok {
+		return nil
+	}
+	seriesID := task.RecurrenceId
+	if seriesID == "" {
+		seriesID = task.Id // e.g. a recurring task created through UpdateTask's upsert
+	}
+	next := &pb.Task{
+		Id:                      uuid.New().String(),
+		Title:                   task.Title,
+		Description:             task.Description,
+		CompleteOnChecklistDone: task.CompleteOnChecklistDone,
+		DueDate:                 due.UTC().Format(time.RFC3339),
+		Recurrence:              task.Recurrence,
+		RecurrenceId:            seriesID,
+		Occurrence:              int32(n),
+		Acl:                     task.Acl,
+		ProjectId:               task.ProjectId,
+		StoryPoints:             task.StoryPoints,
+		Labels:                  task.Labels,
+		AssigneeIds:             task.AssigneeIds,
+		WatcherIds:              task.WatcherIds,
+		EstimateSeconds:         task.EstimateSeconds,
+	}
+	// the next occurrence stays in the task's milestone if due by its end, adding to its burndown's scope
+	if task.MilestoneId != "" {
+		var milestone pb.Milestone
+		err := s.milestonesCol.FindOne(ctx, bson.M{"id": task.MilestoneId}).Decode(&milestone)
+		if err != nil && !errors.Is(err, mongo.ErrNoDocuments) {
+			return err
+		}
+		if err == nil && due.UTC().Format(time.DateOnly) <= milestone.EndDate {
+			next.MilestoneId = task.MilestoneId
+		}
+	}
+	for _, item := range task.Checklist {
+		next.Checklist = append(next.Checklist, &pb.ChecklistItem{Id: uuid.New().String(), Text: item.Text})
+	}
+
+	if full, err := s.atTaskQuota(ctx, 1); err != nil {
+		return err
+	} else if full {
+		return s.taskQuotaError(ctx)
+	}
+	// claim the task first, so completing it concurrently materializes a single next occurrence
+	claim := bson.M{"id": task.Id, "nextoccurrenceid": bson.M{"$in": bson.A{nil, ""}}}
+	res, err := s.mongoCol.UpdateOne(ctx, claim, bson.M{"$set": bson.M{"nextoccurrenceid": next.Id}})
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return nil
+	}
+	if _, err := s.mongoCol.InsertOne(ctx, next); err != nil {
+		// release the claim so completing the task again retries
+		_, _ = s.mongoCol.UpdateOne(context.WithoutCancel(ctx), bson.M{"id": task.Id}, bson.M{"$set": bson.M{"nextoccurrenceid": ""}})
+		return err
+	}
+	s.recordHistory(ctx, next, false)
+	task.NextOccurrenceId = next.Id
+	return nil
+}
+
+// ListOccurrences previews the upcoming occurrences of a recurring task's series,
+// starting with the task itself, whose due dates fall within the requested range.
+func (s *server) ListOccurrences(ctx context.Context, req *pb.OccurrenceRange) (*pb.OccurrenceList, error) {
+	from, err := time.Parse(time.RFC3339, req.From)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "from must be an RFC 3339 timestamp")
+	}
+	to, err := time.Parse(time.RFC3339, req.To)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "to must be an RFC 3339 timestamp")
+	}
+	filter, err := taskFilter(ctx, req.TaskId, readAccess)
+	if err != nil {
+		return nil, err
+	}
+	var task pb.Task
+	if err := s.mongoCol.FindOne(ctx, filter).Decode(&task); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, status.Errorf(codes.NotFound, "task with id %s not found", req.TaskId)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get task: %v", err)
+	}
+	if task.Recurrence == "" {
+		return nil, status.Errorf(codes.FailedPrecondition, "task with id %s is not recurring", req.TaskId)
+	}
+	rule, err := recurrence.Parse(task.Recurrence)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "stored recurrence rule is invalid: %v", err)
+	}
+	due, err := time.Parse(time.RFC3339, task.DueDate)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "stored due_date is invalid: %v", err)
+	}
+
+	list := &pb.OccurrenceList{Occurrences: []*pb.Occurrence{}}
+	it := rule.Iter(due, max(int(task.Occurrence), 1))
+	for scanned := 0; scanned < maxScannedOccurrences && len(list.Occurrences) < maxOccurrences; scanned++ {
+		at, n, ok := it.Next()
+		if !ok || at.After(to) {
+			break
+		}
+		if at.Before(from) {
+			continue
+		}
+		list.Occurrences = append(list.Occurrences, &pb.Occurrence{Occurrence: int32(n), DueDate: at.UTC().Format(time.RFC3339)})
+	}
+	return list, nil
+}
@@ -0,0 +1,239 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Cohen-J-Omer/k8-task-mgmt-system/taskmgmt/internal/validator"
+	pb "github.com/Cohen-J-Omer/k8-task-mgmt-system/taskmgmt/proto"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// implements gRPC's TemplateServiceServer interface
+// templateServer stores task templates and instantiates them into the tasks collection.
+type templateServer struct {
+	pb.UnimplementedTemplateServiceServer
+	mongoCol *scopedCollection // collection handler for the "templates" MongoDB collection
+	tasks    *server           // the task service, whose collection the templates are instantiated into
+}
+
+// newTemplateServer creates a templateServer, ensuring template names are unique within each workspace.
+func newTemplateServer(ctx context.Context, templates *mongo.Collection, tasks *server) (*templateServer, error) {
+	scoped, err := newScopedCollection(ctx, templates, "name")
+	if err != nil {
+		return nil, err
+	}
+	return &templateServer{mongoCol: scoped, tasks: tasks}, nil
+}
+
+// CreateTemplate stores a new template, failing if its name is taken.
+func (s *templateServer) CreateTemplate(ctx context.Context, req *pb.TaskTemplate) (*pb.TaskTemplate, error) {
+	if err := validator.ValidateTemplate(req); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if _, err := s.mongoCol.InsertOne(ctx, req); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return nil, status.Errorf(codes.AlreadyExists, "template %s already exists", req.Name)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to create template: %v", err)
+	}
+	return req, nil
+}
+
+// GetTemplate retrieves a template by its name.
+func (s *templateServer) GetTemplate(ctx context.Context, req *pb.TemplateName) (*pb.TaskTemplate, error) {
+	var tmpl pb.TaskTemplate
+	if err := s.mongoCol.FindOne(ctx, bson.M{"name": req.Name}).Decode(&tmpl); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, status.Errorf(codes.NotFound, "template %s not found", req.Name)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get template: %v", err)
+	}
+	return &tmpl, nil
+}
+
+// GetTemplates retrieves all templates ordered by name.
+func (s *templateServer) GetTemplates(ctx context.Context, _ *pb.Empty) (*pb.TemplateList, error) {
+	cursor, err := s.mongoCol.Find(ctx, bson.M{}, options.Find().SetSort(bson.D{{Key: "name", Value: 1}}))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list templates: %v", err)
+	}
+	list := &pb.TemplateList{Templates: []*pb.TaskTemplate{}}
+	if err := cursor.All(ctx, &list.Templates); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list templates: %v", err)
+	}
+	return list, nil
+}
+
+// UpdateTemplate replaces a template, creating it if it does not exist.
+func (s *templateServer) UpdateTemplate(ctx context.Context, req *pb.TaskTemplate) (*pb.TaskTemplate, error) {
+	if err := validator.ValidateTemplate(req); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	_, err := s.mongoCol.ReplaceOne(ctx, bson.M{"name": req.Name}, req, options.Replace().SetUpsert(true))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to update template: %v", err)
+	}
+	return req, nil
+}
+
+// DeleteTemplate deletes a template by its name, returning the deleted template.
+func (s *templateServer) DeleteTemplate(ctx context.Context, req *pb.TemplateName) (*pb.TaskTemplate, error) {
+	var tmpl pb.TaskTemplate
+	if err := s.mongoCol.FindOneAndDelete(ctx, bson.M{"name": req.Name}).Decode(&tmpl); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, status.Errorf(codes.NotFound, "template %s not found", req.Name)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to delete template: %v", err)
+	}
+	return &tmpl, nil
+}
+
+// InstantiateTemplate creates the template's whole task tree in a single insert,
+// with variables filled in and relative due dates resolved against the base date.
+func (s *templateServer) InstantiateTemplate(ctx context.Context, req *pb.InstantiateRequest) (*pb.TaskList, error) {
+	base := time.Now().UTC()
+	if req.BaseDate != "" {
+		t, err := time.Parse(time.RFC3339, req.BaseDate)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "base_date must be an RFC 3339 timestamp")
+		}
+		base = t
+	}
+	tmpl, err := s.GetTemplate(ctx, &pb.TemplateName{Name: req.Name})
+	if err != nil {
+		return nil, err
+	}
+	if missing := missingVariables(tmpl.Root, req.Variables); len(missing) > 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "missing template variables: %s", strings.Join(missing, ", "))
+	}
+
+	if req.MilestoneId != "" {
+		if err := requireMilestoneExists(ctx, s.tasks.milestonesCol, req.MilestoneId); err != nil {
+			return nil, err
+		}
+	}
+
+	var tasks []*pb.Task
+	if err := buildTasks(tmpl.Root, "", req.Variables, base, &tasks); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	// the caller owns the tasks created from the template
+	owner, err := resolveUser(ctx, "me")
+	if err != nil {
+		return nil, err
+	}
+	for _, task := range tasks {
+		task.Acl = &pb.TaskACL{OwnerId: owner}
+		task.MilestoneId = req.MilestoneId
+	}
+	// the whole tree must fit in the workspace's quota
+	if full, err := s.tasks.atTaskQuota(ctx, int64(len(tasks))); err != nil {
+		return nil, err
+	} else if full {
+		return nil, s.tasks.taskQuotaError(ctx)
+	}
+	docs := make([]interface{}, len(tasks))
+	for i, task := range tasks {
+		docs[i] = task
+	}
+	if _, err := s.tasks.mongoCol.InsertMany(ctx, docs); err != nil {
+		// don't leave a partial task tree behind
+		ids := make([]string, len(tasks))
+		for i, task := range tasks {
+			ids[i] = task.Id
+		}
+		if _, delErr := s.tasks.mongoCol.DeleteMany(context.WithoutCancel(ctx), bson.M{"id": bson.M{"$in": ids}}); delErr != nil {
+			slog.ErrorContext(ctx, "failed to clean up partially instantiated template", "template", req.Name, "error", delErr)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to instantiate template: %v", err)
+	}
+	// the tasks joining a milestone add to its burndown's scope
+	for _, task := range tasks {
+		s.tasks.recordHistory(ctx, task, false)
+		computeFields(task)
+	}
+	return &pb.TaskList{Tasks: tasks}, nil
+}
+
+var templateVarRe = regexp.MustCompile(`\{\{\s*([A-Za-z_][A-Za-z0-9_]*)\s*\}\}`)
+
+// substitute fills in the {{variables}} of s.
+func substitute(s string, vars map[string]string) string {
+	return templateVarRe.ReplaceAllStringFunc(s, func(m string) string {
+		return vars[templateVarRe.FindStringSubmatch(m)[1]]
+	})
+}
+
+// missingVariables returns the sorted names of the variables referenced by the task tree but not provided.
+func missingVariables(root *pb.TemplateTask, vars map[string]string) []string {
+	seen := map[string]bool{}
+	var walk func(task *pb.TemplateTask)
+	walk = func(task *pb.TemplateTask) {
+		texts := append([]string{task.Title, task.Description}, task.Checklist...)
+		for _, text := range texts {
+			for _, m := range templateVarRe.FindAllStringSubmatch(text, -1) {
+				if _, ok := vars[m[1]]; !ok {
+					seen[m[1]] = true
+				}
+			}
+		}
+		for _, sub := range task.Subtasks {
+			walk(sub)
+		}
+	}
+	walk(root)
+	missing := make([]string, 0, len(seen))
+	for name := range seen {
+		missing = append(missing, name)
+	}
+	sort.Strings(missing)
+	return missing
+}
+
+// buildTasks appends the task created from tmpl and, depth first, those of its subtasks to tasks.
+func buildTasks(tmpl *pb.TemplateTask, parentID string, vars map[string]string, base time.Time, tasks *[]*pb.Task) error {
+	task := &pb.Task{
+		Id:          uuid.New().String(),
+		Title:       substitute(tmpl.Title, vars),
+		Description: substitute(tmpl.Description, vars),
+		Recurrence:  tmpl.Recurrence,
+		ParentId:    parentID,
+		StoryPoints: tmpl.StoryPoints,
+	}
+	for _, text := range tmpl.Checklist {
+		task.Checklist = append(task.Checklist, &pb.ChecklistItem{Id: uuid.New().String(), Text: substitute(text, vars)})
+	}
+	if tmpl.DueIn != "" {
+		offset, err := validator.ParseRelativeDuration(tmpl.DueIn)
+		if err != nil {
+			return err
+		}
+		task.DueDate = base.Add(offset).Format(time.RFC3339)
+	}
+	// the filled in task must be as valid as one created directly
+	if err := validator.ValidateTaskCreate(task); err != nil {
+		return err
+	}
+	if task.Recurrence != "" {
+		task.RecurrenceId, task.Occurrence = task.Id, 1
+	}
+	*tasks = append(*tasks, task)
+	for _, sub := range tmpl.Subtasks {
+		if err := buildTasks(sub, task.Id, vars, base, tasks); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,263 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Cohen-J-Omer/k8-task-mgmt-system/taskmgmt/internal/identity"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// workspaceField holds the workspace of each document of a scoped collection.
+const workspaceField = "workspaceid"
+
+// scopedCollection is a MongoDB collection shared by all workspaces. Every operation is restricted to
+// the caller's workspace, taken from the request's context, so that a query missing a filter can't
+// reach another workspace's documents: filters are narrowed to the workspace, inserted documents are
+// stamped with it and aggregations start by matching it. Pipelines must not $lookup other collections.
+// Queries are also limited to the time left until the request's deadline, so MongoDB stops running them
+// once the caller gave up.
+type scopedCollection struct {
+	col *mongo.Collection
+}
+
+// newScopedCollection scopes col by workspace, assigning documents stored before workspaces existed to
+// the default workspace, and indexing the workspace together with the key unique within each workspace.
+func newScopedCollection(ctx context.Context, col *mongo.Collection, uniqueKey string) (*scopedCollection, error) {
+	_, err := col.UpdateMany(ctx,
+		bson.M{workspaceField: bson.M{"$exists": false}},
+		bson.M{"$set": bson.M{workspaceField: identity.DefaultWorkspace}})
+	if err != nil {
+		return nil, err
+	}
+	// the key used to be unique across the whole collection
+	if err := dropIndexIfExists(ctx, col, uniqueKey+"_1"); err != nil {
+		return nil, err
+	}
+	_, err = col.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: workspaceField, Value: 1}, {Key: uniqueKey, Value: 1}},
+		Options: options.Index().SetUnique(true),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &scopedCollection{col: col}, nil
+}
+
+// dropIndexIfExists drops the named index, ignoring a missing index or collection.
+func dropIndexIfExists(ctx context.Context, col *mongo.Collection, name string) error {
+	_, err := col.Indexes().DropOne(ctx, name)
+	var cmdErr mongo.CommandError
+	if errors.As(err, &cmdErr) && (cmdErr.Name == "IndexNotFound" || cmdErr.Name == "NamespaceNotFound") {
+		return nil
+	}
+	return err
+}
+
+// Unscoped returns the collection across all workspaces, for the few lookups that determine the
+// caller's workspace rather than run within it, such as authenticating an API key.
+func (c *scopedCollection) Unscoped() *mongo.Collection {
+	return c.col
+}
+
+// workspaceOf returns the workspace of the caller of the request.
+func workspaceOf(ctx context.Context) (string, error) {
+	p, ok := identity.FromIncomingContext(ctx)
+	if !ok {
+		return "", status.Error(codes.Unauthenticated, "caller is not identified")
+	}
+	if p.WorkspaceID == "" {
+		return "", status.Error(codes.Unauthenticated, "caller has no workspace")
+	}
+	return p.WorkspaceID, nil
+}
+
+// scopeFilter narrows filter to the caller's workspace. Equality on the workspace is kept at the top
+// level of bson.M filters, so documents inserted by upserts belong to the workspace too.
+func scopeFilter(ctx context.Context, filter interface{}) (interface{}, error) {
+	workspace, err := workspaceOf(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if m, ok := filter.(bson.M); ok {
+		scoped := make(bson.M, len(m)+1)
+		for k, v := range m {
+			scoped[k] = v
+		}
+		scoped[workspaceField] = workspace
+		return scoped, nil
+	}
+	return bson.M{"$and": bson.A{bson.M{workspaceField: workspace}, filter}}, nil
+}
+
+// scopeDocument returns doc stamped with the caller's workspace.
+func scopeDocument(ctx context.Context, doc interface{}) (bson.D, error) {
+	workspace, err := workspaceOf(ctx)
+	if err != nil {
+		return nil, err
+	}
+	raw, err := bson.Marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+	var fields bson.D
+	if err := bson.Unmarshal(raw, &fields); err != nil {
+		return nil, err
+	}
+	scoped := bson.D{{Key: workspaceField, Value: workspace}}
+	for _, f := range fields {
+		if f.Key != workspaceField {
+			scoped = append(scoped, f)
+		}
+	}
+	return scoped, nil
+}
+
+func (c *scopedCollection) FindOne(ctx context.Context, filter interface{}, opts ...*options.FindOneOptions) *mongo.SingleResult {
+	scoped, err := scopeFilter(ctx, filter)
+	if err != nil {
+		return mongo.NewSingleResultFromDocument(bson.D{}, err, nil)
+	}
+	if d, ok := maxTime(ctx); ok {
+		opts = append([]*options.FindOneOptions{options.FindOne().SetMaxTime(d)}, opts...)
+	}
+	return c.col.FindOne(ctx, scoped, opts...)
+}
+
+func (c *scopedCollection) Find(ctx context.Context, filter interface{}, opts ...*options.FindOptions) (*mongo.Cursor, error) {
+	scoped, err := scopeFilter(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	if d, ok := maxTime(ctx); ok {
+		opts = append([]*options.FindOptions{options.Find().SetMaxTime(d)}, opts...)
+	}
+	return c.col.Find(ctx, scoped, opts...)
+}
+
+func (c *scopedCollection) CountDocuments(ctx context.Context, filter interface{}, opts ...*options.CountOptions) (int64, error) {
+	scoped, err := scopeFilter(ctx, filter)
+	if err != nil {
+		return 0, err
+	}
+	if d, ok := maxTime(ctx); ok {
+		opts = append([]*options.CountOptions{options.Count().SetMaxTime(d)}, opts...)
+	}
+	return c.col.CountDocuments(ctx, scoped, opts...)
+}
+
+func (c *scopedCollection) Aggregate(ctx context.Context, pipeline mongo.Pipeline, opts ...*options.AggregateOptions) (*mongo.Cursor, error) {
+	workspace, err := workspaceOf(ctx)
+	if err != nil {
+		return nil, err
+	}
+	scoped := append(mongo.Pipeline{{{Key: "$match", Value: bson.M{workspaceField: workspace}}}}, pipeline...)
+	if d, ok := maxTime(ctx); ok {
+		opts = append([]*options.AggregateOptions{options.Aggregate().SetMaxTime(d)}, opts...)
+	}
+	return c.col.Aggregate(ctx, scoped, opts...)
+}
+
+func (c *scopedCollection) InsertOne(ctx context.Context, doc interface{}, opts ...*options.InsertOneOptions) (*mongo.InsertOneResult, error) {
+	scoped, err := scopeDocument(ctx, doc)
+	if err != nil {
+		return nil, err
+	}
+	return c.col.InsertOne(ctx, scoped, opts...)
+}
+
+func (c *scopedCollection) InsertMany(ctx context.Context, docs []interface{}, opts ...*options.InsertManyOptions) (*mongo.InsertManyResult, error) {
+	scoped := make([]interface{}, len(docs))
+	for i, doc := range docs {
+		d, err := scopeDocument(ctx, doc)
+		if err != nil {
+			return nil, err
+		}
+		scoped[i] = d
+	}
+	return c.col.InsertMany(ctx, scoped, opts...)
+}
+
+func (c *scopedCollection) ReplaceOne(ctx context.Context, filter, replacement interface{}, opts ...*options.ReplaceOptions) (*mongo.UpdateResult, error) {
+	scoped, err := scopeFilter(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	doc, err := scopeDocument(ctx, replacement)
+	if err != nil {
+		return nil, err
+	}
+	return c.col.ReplaceOne(ctx, scoped, doc, opts...)
+}
+
+func (c *scopedCollection) UpdateOne(ctx context.Context, filter, update interface{}, opts ...*options.UpdateOptions) (*mongo.UpdateResult, error) {
+	scoped, err := scopeFilter(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	return c.col.UpdateOne(ctx, scoped, update, opts...)
+}
+
+func (c *scopedCollection) FindOneAndUpdate(ctx context.Context, filter, update interface{}, opts ...*options.FindOneAndUpdateOptions) *mongo.SingleResult {
+	scoped, err := scopeFilter(ctx, filter)
+	if err != nil {
+		return mongo.NewSingleResultFromDocument(bson.D{}, err, nil)
+	}
+	if d, ok := maxTime(ctx); ok {
+		opts = append([]*options.FindOneAndUpdateOptions{options.FindOneAndUpdate().SetMaxTime(d)}, opts...)
+	}
+	return c.col.FindOneAndUpdate(ctx, scoped, update, opts...)
+}
+
+func (c *scopedCollection) FindOneAndDelete(ctx context.Context, filter interface{}, opts ...*options.FindOneAndDeleteOptions) *mongo.SingleResult {
+	scoped, err := scopeFilter(ctx, filter)
+	if err != nil {
+		return mongo.NewSingleResultFromDocument(bson.D{}, err, nil)
+	}
+	if d, ok := maxTime(ctx); ok {
+		opts = append([]*options.FindOneAndDeleteOptions{options.FindOneAndDelete().SetMaxTime(d)}, opts...)
+	}
+	return c.col.FindOneAndDelete(ctx, scoped, opts...)
+}
+
+func (c *scopedCollection) DeleteOne(ctx context.Context, filter interface{}, opts ...*options.DeleteOptions) (*mongo.DeleteResult, error) {
+	scoped, err := scopeFilter(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	return c.col.DeleteOne(ctx, scoped, opts...)
+}
+
+func (c *scopedCollection) DeleteMany(ctx context.Context, filter interface{}, opts ...*options.DeleteOptions) (*mongo.DeleteResult, error) {
+	scoped, err := scopeFilter(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	return c.col.DeleteMany(ctx, scoped, opts...)
+}
+
+// BulkWrite runs the update models in a single call, each narrowed to the caller's workspace. Other
+// models, and upserts, are rejected, as the documents they'd insert wouldn't be stamped with it.
+func (c *scopedCollection) BulkWrite(ctx context.Context, models []mongo.WriteModel, opts ...*options.BulkWriteOptions) (*mongo.BulkWriteResult, error) {
+	scoped := make([]mongo.WriteModel, len(models))
+	for i, model := range models {
+		update, ok := model.(*mongo.UpdateOneModel)
+		if !ok || (update.Upsert != nil && *update.Upsert) {
+			return nil, fmt.Errorf("unsupported bulk write model %T, only updates of existing documents are scoped", model)
+		}
+		filter, err := scopeFilter(ctx, update.Filter)
+		if err != nil {
+			return nil, err
+		}
+		u := *update
+		u.Filter = filter
+		scoped[i] = &u
+	}
+	return c.col.BulkWrite(ctx, scoped, opts...)
+}
@@ -0,0 +1,148 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Cohen-J-Omer/k8-task-mgmt-system/taskmgmt/internal/identity"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// callerIn returns the context of a request made by a user of the workspace.
+func callerIn(workspace string) context.Context {
+	return metadata.NewIncomingContext(context.Background(), metadata.Pairs(
+		identity.UserIDMetadataKey, "user-1",
+		identity.WorkspaceMetadataKey, workspace,
+	))
+}
+
+// sentWorkspace returns the workspace the filter of the command sent to MongoDB equals, if any.
+func sentWorkspace(t *testing.T, filter bson.Raw) string {
+	t.Helper()
+	ws, ok := filter.Lookup(workspaceField).StringValueOK()
+	if !ok {
+		t.Fatalf("filter %s doesn't match a workspace", filter)
+	}
+	return ws
+}
+
+func TestScopedCollectionIsolatesWorkspaces(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("find is narrowed to the caller's workspace", func(mt *mtest.T) {
+		col := &scopedCollection{col: mt.Coll}
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "db.tasks", mtest.FirstBatch))
+
+		// a filter naming another workspace must not reach it
+		cur, err := col.Find(callerIn("a"), bson.M{"id": "t1", workspaceField: "b"})
+		if err != nil {
+			mt.Fatalf("Find: %v", err)
+		}
+		_ = cur.Close(context.Background())
+
+		filter := mt.GetStartedEvent().Command.Lookup("filter").Document()
+		if ws := sentWorkspace(mt.T, bson.Raw(filter)); ws != "a" {
+			mt.Errorf("Find sent workspace %q, want %q", ws, "a")
+		}
+	})
+
+	mt.Run("non-map filters are combined with the workspace", func(mt *mtest.T) {
+		col := &scopedCollection{col: mt.Coll}
+		mt.AddMockResponses(mtest.CreateSuccessResponse(bson.E{Key: "n", Value: 0}))
+
+		if _, err := col.DeleteMany(callerIn("a"), bson.D{{Key: "id", Value: "t1"}}); err != nil {
+			mt.Fatalf("DeleteMany: %v", err)
+		}
+
+		deletes, err := mt.GetStartedEvent().Command.Lookup("deletes").Array().Values()
+		if err != nil {
+			mt.Fatalf("deletes: %v", err)
+		}
+		and := deletes[0].Document().Lookup("q", "$and").Array()
+		if ws := sentWorkspace(mt.T, bson.Raw(and.Index(0).Value().Document())); ws != "a" {
+			mt.Errorf("DeleteMany sent workspace %q, want %q", ws, "a")
+		}
+	})
+
+	mt.Run("inserted documents are stamped with the caller's workspace", func(mt *mtest.T) {
+		col := &scopedCollection{col: mt.Coll}
+		mt.AddMockResponses(mtest.CreateSuccessResponse())
+
+		if _, err := col.InsertOne(callerIn("a"), bson.M{"id": "t1", workspaceField: "b"}); err != nil {
+			mt.Fatalf("InsertOne: %v", err)
+		}
+
+		docs, err := mt.GetStartedEvent().Command.Lookup("documents").Array().Values()
+		if err != nil {
+			mt.Fatalf("documents: %v", err)
+		}
+		if ws := sentWorkspace(mt.T, bson.Raw(docs[0].Document())); ws != "a" {
+			mt.Errorf("InsertOne stored workspace %q, want %q", ws, "a")
+		}
+	})
+
+	mt.Run("aggregations start by matching the caller's workspace", func(mt *mtest.T) {
+		col := &scopedCollection{col: mt.Coll}
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "db.tasks", mtest.FirstBatch))
+
+		pipeline := mongo.Pipeline{{{Key: "$match", Value: bson.M{"status": "open"}}}}
+		cur, err := col.Aggregate(callerIn("a"), pipeline)
+		if err != nil {
+			mt.Fatalf("Aggregate: %v", err)
+		}
+		_ = cur.Close(context.Background())
+
+		first := mt.GetStartedEvent().Command.Lookup("pipeline").Array().Index(0).Value().Document()
+		if ws := sentWorkspace(mt.T, bson.Raw(first.Lookup("$match").Document())); ws != "a" {
+			mt.Errorf("Aggregate matched workspace %q, want %q", ws, "a")
+		}
+	})
+
+	mt.Run("bulk updates are narrowed and upserts rejected", func(mt *mtest.T) {
+		col := &scopedCollection{col: mt.Coll}
+		mt.AddMockResponses(mtest.CreateSuccessResponse(bson.E{Key: "n", Value: 1}, bson.E{Key: "nModified", Value: 1}))
+
+		update := mongo.NewUpdateOneModel().SetFilter(bson.M{"id": "t1"}).SetUpdate(bson.M{"$set": bson.M{"rank": "m"}})
+		if _, err := col.BulkWrite(callerIn("a"), []mongo.WriteModel{update}); err != nil {
+			mt.Fatalf("BulkWrite: %v", err)
+		}
+		updates, err := mt.GetStartedEvent().Command.Lookup("updates").Array().Values()
+		if err != nil {
+			mt.Fatalf("updates: %v", err)
+		}
+		if ws := sentWorkspace(mt.T, bson.Raw(updates[0].Document().Lookup("q").Document())); ws != "a" {
+			mt.Errorf("BulkWrite sent workspace %q, want %q", ws, "a")
+		}
+		if update.Filter.(bson.M)[workspaceField] != nil {
+			mt.Errorf("BulkWrite modified the caller's model")
+		}
+
+		upsert := mongo.NewUpdateOneModel().SetFilter(bson.M{"id": "t2"}).SetUpdate(bson.M{"$set": bson.M{"rank": "n"}}).SetUpsert(true)
+		if _, err := col.BulkWrite(callerIn("a"), []mongo.WriteModel{upsert}); err == nil {
+			mt.Errorf("BulkWrite accepted an upsert")
+		}
+	})
+
+	mt.Run("callers without a workspace are rejected before querying", func(mt *mtest.T) {
+		col := &scopedCollection{col: mt.Coll}
+
+		for name, ctx := range map[string]context.Context{
+			"anonymous":    context.Background(),
+			"no workspace": metadata.NewIncomingContext(context.Background(), metadata.Pairs(identity.UserIDMetadataKey, "user-1")),
+		} {
+			err := col.FindOne(ctx, bson.M{"id": "t1"}).Err()
+			if status.Code(err) != codes.Unauthenticated {
+				mt.Errorf("%s: FindOne returned %v, want Unauthenticated", name, err)
+			}
+		}
+		if ev := mt.GetStartedEvent(); ev != nil {
+			mt.Errorf("sent %s without a workspace", ev.CommandName)
+		}
+	})
+}
@@ -0,0 +1,269 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+
+	"github.com/Cohen-J-Omer/k8-task-mgmt-system/taskmgmt/internal/identity"
+	"github.com/Cohen-J-Omer/k8-task-mgmt-system/taskmgmt/internal/validator"
+	pb "github.com/Cohen-J-Omer/k8-task-mgmt-system/taskmgmt/proto"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// implements gRPC's UserServiceServer interface
+// userServer manages the users tasks can be assigned to.
+type userServer struct {
+	pb.UnimplementedUserServiceServer
+	mongoCol      *scopedCollection // collection handler for the "users" MongoDB collection
+	teamsCol      *scopedCollection // collection handler for the "teams" MongoDB collection
+	workspacesCol *mongo.Collection // collection handler for the "workspaces" MongoDB collection
+}
+
+// newUserServer creates a userServer, ensuring user and team IDs are unique within each workspace.
+func newUserServer(ctx context.Context, users, teams, workspaces *mongo.Collection) (*userServer, error) {
+	scopedUsers, err := newScopedCollection(ctx, users, "id")
+	if err != nil {
+		return nil, err
+	}
+	scopedTeams, err := newScopedCollection(ctx, teams, "id")
+	if err != nil {
+		return nil, err
+	}
+	return &userServer{mongoCol: scopedUsers, teamsCol: scopedTeams, workspacesCol: workspaces}, nil
+}
+
+// CreateUser stores a new user, failing if its ID is taken.
+func (s *userServer) CreateUser(ctx context.Context, req *pb.User) (*pb.User, error) {
+	if err := validator.ValidateUser(req); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	// operators may pick any workspace to create users in, which must have been created first
+	workspace, err := workspaceOf(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := requireWorkspaceExists(ctx, s.workspacesCol, workspace); err != nil {
+		return nil, err
+	}
+	if _, err := s.mongoCol.InsertOne(ctx, req); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return nil, status.Errorf(codes.AlreadyExists, "user %s already exists", req.Id)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to create user: %v", err)
+	}
+	return req, nil
+}
+
+// GetUser retrieves a user by its ID.
+func (s *userServer) GetUser(ctx context.Context, req *pb.UserID) (*pb.User, error) {
+	var user pb.User
+	if err := s.mongoCol.FindOne(ctx, bson.M{"id": req.Id}).Decode(&user); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, status.Errorf(codes.NotFound, "user %s not found", req.Id)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get user: %v", err)
+	}
+	return &user, nil
+}
+
+// GetUsers retrieves all users ordered by ID.
+func (s *userServer) GetUsers(ctx context.Context, _ *pb.Empty) (*pb.UserList, error) {
+	cursor, err := s.mongoCol.Find(ctx, bson.M{}, options.Find().SetSort(bson.D{{Key: "id", Value: 1}}))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list users: %v", err)
+	}
+	list := &pb.UserList{Users: []*pb.User{}}
+	if err := cursor.All(ctx, &list.Users); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list users: %v", err)
+	}
+	return list, nil
+}
+
+// UpdateUser replaces a user's name, email and role.
+func (s *userServer) UpdateUser(ctx context.Context, req *pb.User) (*pb.User, error) {
+	if err := validator.ValidateUser(req); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	var user pb.User
+	update := bson.M{"$set": bson.M{"name": req.Name, "email": req.Email, "role": req.Role}}
+	err := s.mongoCol.FindOneAndUpdate(ctx, bson.M{"id": req.Id}, update, options.FindOneAndUpdate().SetReturnDocument(options.After)).Decode(&user)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, status.Errorf(codes.NotFound, "user %s not found", req.Id)
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to update user: %v", err)
+	}
+	return &user, nil
+}
+
+// CreateTeam stores a new team, failing if its ID is taken or a member is unknown.
+func (s *userServer) CreateTeam(ctx context.Context, req *pb.Team) (*pb.Team, error) {
+	if err := validator.ValidateTeam(req); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err := requireUsersExist(ctx, s.mongoCol, req.MemberIds); err != nil {
+		return nil, err
+	}
+	if req.MemberIds == nil {
+		req.MemberIds = []string{}
+	}
+	if _, err := s.teamsCol.InsertOne(ctx, req); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return nil, status.Errorf(codes.AlreadyExists, "team %s already exists", req.Id)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to create team: %v", err)
+	}
+	return req, nil
+}
+
+// GetTeam retrieves a team by its ID.
+func (s *userServer) GetTeam(ctx context.Context, req *pb.TeamID) (*pb.Team, error) {
+	var team pb.Team
+	if err := s.teamsCol.FindOne(ctx, bson.M{"id": req.Id}).Decode(&team); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, status.Errorf(codes.NotFound, "team %s not found", req.Id)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get team: %v", err)
+	}
+	return &team, nil
+}
+
+// GetTeams retrieves all teams ordered by ID.
+func (s *userServer) GetTeams(ctx context.Context, _ *pb.Empty) (*pb.TeamList, error) {
+	cursor, err := s.teamsCol.Find(ctx, bson.M{}, options.Find().SetSort(bson.D{{Key: "id", Value: 1}}))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list teams: %v", err)
+	}
+	list := &pb.TeamList{Teams: []*pb.Team{}}
+	if err := cursor.All(ctx, &list.Teams); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list teams: %v", err)
+	}
+	return list, nil
+}
+
+// AddTeamMember adds an existing user to the team.
+func (s *userServer) AddTeamMember(ctx context.Context, req *pb.TeamMember) (*pb.Team, error) {
+	if err := requireUsersExist(ctx, s.mongoCol, []string{req.UserId}); err != nil {
+		return nil, err
+	}
+	return s.updateTeamMembers(ctx, req, "$addToSet")
+}
+
+// RemoveTeamMember removes a user from the team.
+func (s *userServer) RemoveTeamMember(ctx context.Context, req *pb.TeamMember) (*pb.Team, error) {
+	return s.updateTeamMembers(ctx, req, "$pull")
+}
+
+// updateTeamMembers adds ($addToSet) or removes ($pull) a user to/from the team's members.
+func (s *userServer) updateTeamMembers(ctx context.Context, req *pb.TeamMember, op string) (*pb.Team, error) {
+	var team pb.Team
+	update := bson.M{op: bson.M{"memberids": req.UserId}}
+	err := s.teamsCol.FindOneAndUpdate(ctx, bson.M{"id": req.TeamId}, update, options.FindOneAndUpdate().SetReturnDocument(options.After)).Decode(&team)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, status.Errorf(codes.NotFound, "team %s not found", req.TeamId)
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to update team: %v", err)
+	}
+	return &team, nil
+}
+
+// resolveUser replaces an empty or "me" user ID by the calling user's ID.
+func resolveUser(ctx context.Context, userID string) (string, error) {
+	if userID != "" && userID != "me" {
+		return userID, nil
+	}
+	p, ok := identity.FromIncomingContext(ctx)
+	if !ok {
+		return "", status.Error(codes.InvalidArgument, "user is required when the caller is not identified")
+	}
+	return p.UserID, nil
+}
+
+// requireUsersExist fails with InvalidArgument unless every user ID refers to an existing user.
+func requireUsersExist(ctx context.Context, users *scopedCollection, userIDs []string) error {
+	if len(userIDs) == 0 {
+		return nil
+	}
+	unique := map[string]bool{}
+	for _, id := range userIDs {
+		unique[id] = true
+	}
+	ids := make([]string, 0, len(unique))
+	for id := range unique {
+		ids = append(ids, id)
+	}
+	cursor, err := users.Find(ctx, bson.M{"id": bson.M{"$in": ids}}, options.Find().SetProjection(bson.M{"id": 1}))
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to look up users: %v", err)
+	}
+	var found []*pb.User
+	if err := cursor.All(ctx, &found); err != nil {
+		return status.Errorf(codes.Internal, "failed to look up users: %v", err)
+	}
+	for _, user := range found {
+		delete(unique, user.Id)
+	}
+	if len(unique) > 0 {
+		missing := make([]string, 0, len(unique))
+		for id := range unique {
+			missing = append(missing, id)
+		}
+		sort.Strings(missing)
+		return status.Errorf(codes.InvalidArgument, "unknown users: %s", strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// AddAssignee assigns a user to the task.
+func (s *server) AddAssignee(ctx context.Context, req *pb.TaskUser) (*pb.Task, error) {
+	return s.updateTaskUsers(ctx, req, "$addToSet", "assigneeids")
+}
+
+// RemoveAssignee unassigns a user from the task.
+func (s *server) RemoveAssignee(ctx context.Context, req *pb.TaskUser) (*pb.Task, error) {
+	return s.updateTaskUsers(ctx, req, "$pull", "assigneeids")
+}
+
+// AddWatcher adds a user to the task's watchers.
+func (s *server) AddWatcher(ctx context.Context, req *pb.TaskUser) (*pb.Task, error) {
+	return s.updateTaskUsers(ctx, req, "$addToSet", "watcherids")
+}
+
+// RemoveWatcher removes a user from the task's watchers.
+func (s *server) RemoveWatcher(ctx context.Context, req *pb.TaskUser) (*pb.Task, error) {
+	return s.updateTaskUsers(ctx, req, "$pull", "watcherids")
+}
+
+// updateTaskUsers adds ($addToSet) or removes ($pull) a user to/from one of the task's user ID lists.
+func (s *server) updateTaskUsers(ctx context.Context, req *pb.TaskUser, op, field string) (*pb.Task, error) {
+	if err := s.requireTaskAccess(ctx, req.TaskId, writeAccess); err != nil {
+		return nil, err
+	}
+	userID, err := resolveUser(ctx, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+	if op == "$addToSet" {
+		if err := requireUsersExist(ctx, s.usersCol, []string{userID}); err != nil {
+			return nil, err
+		}
+	}
+	var task pb.Task
+	update := bson.M{op: bson.M{field: userID}}
+	err = s.mongoCol.FindOneAndUpdate(ctx, bson.M{"id": req.TaskId}, update, options.FindOneAndUpdate().SetReturnDocument(options.After)).Decode(&task)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, status.Errorf(codes.NotFound, "task with id %s not found", req.TaskId)
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to update task: %v", err)
+	}
+	return computeFields(&task), nil
+}
@@ -0,0 +1,229 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Cohen-J-Omer/k8-task-mgmt-system/taskmgmt/internal/validator"
+	pb "github.com/Cohen-J-Omer/k8-task-mgmt-system/taskmgmt/proto"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// loggedSeconds returns the total duration of a task's worklogs.
+func loggedSeconds(worklogs []*pb.Worklog) int64 {
+	var total int64
+	for _, w := range worklogs {
+		total += w.DurationSeconds
+	}
+	return total
+}
+
+// StartTimer starts a timer of the user, by default the caller, on the task.
+// A user may only run a single timer per task.
+func (s *server) StartTimer(ctx context.Context, req *pb.TimerRequest) (*pb.Task, error) {
+	if err := s.requireTaskAccess(ctx, req.TaskId, writeAccess); err != nil {
+		return nil, err
+	}
+	user, err := resolveUser(ctx, req.User)
+	if err != nil {
+		return nil, err
+	}
+	req.User = user
+	timer := &pb.Timer{User: req.User, StartedAt: time.Now().UTC().Format(time.RFC3339)}
+	// only match tasks without a running timer of the user
+	filter := bson.M{"id": req.TaskId, "timers.user": bson.M{"$ne": req.User}}
+	update := bson.M{"$push": bson.M{"timers": timer}}
+	var task pb.Task
+	err = s.mongoCol.FindOneAndUpdate(ctx, filter, update, options.FindOneAndUpdate().SetReturnDocument(options.After)).Decode(&task)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		if err := s.mongoCol.FindOne(ctx, bson.M{"id": req.TaskId}).Err(); err == nil {
+			return nil, status.Errorf(codes.FailedPrecondition, "a timer of %s is already running on task %s", req.User, req.TaskId)
+		}
+		return nil, status.Errorf(codes.NotFound, "task with id %s not found", req.TaskId)
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to start timer: %v", err)
+	}
+	return computeFields(&task), nil
+}
+
+// StopTimer stops the running timer of the user, by default the caller, on the task and logs
+// the elapsed time as a worklog, both in a single update so no tracked time is lost.
+func (s *server) StopTimer(ctx context.Context, req *pb.TimerRequest) (*pb.Task, error) {
+	if err := s.requireTaskAccess(ctx, req.TaskId, writeAccess); err != nil {
+		return nil, err
+	}
+	user, err := resolveUser(ctx, req.User)
+	if err != nil {
+		return nil, err
+	}
+	req.User = user
+	var task pb.Task
+	if err := s.mongoCol.FindOne(ctx, bson.M{"id": req.TaskId, "timers.user": req.User}).Decode(&task); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, status.Errorf(codes.NotFound, "no timer of %s is running on task %s", req.User, req.TaskId)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get task: %v", err)
+	}
+	var timer *pb.Timer
+	for _, t := range task.Timers {
+		if t.User == req.User {
+			timer = t
+		}
+	}
+	startedAt, err := time.Parse(time.RFC3339, timer.StartedAt)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "stored timer start is invalid: %v", err)
+	}
+	worklog := &pb.Worklog{
+		Id:   uuid.New().String(),
+		User: req.User,
+		Date: timer.StartedAt,
+		// a timer stopped within its first second still logs a second of work
+		DurationSeconds: max(int64(time.Since(startedAt)/time.Second), 1),
+		Note:            req.Note,
+	}
+	// the timer must still be the one read above, so a concurrent stop can't log it twice
+	filter := bson.M{"id": req.TaskId, "timers": bson.M{"$elemMatch": bson.M{"user": req.User, "startedat": timer.StartedAt}}}
+	update := bson.M{
+		"$pull": bson.M{"timers": bson.M{"user": req.User}},
+		"$push": bson.M{"worklogs": worklog},
+	}
+	err = s.mongoCol.FindOneAndUpdate(ctx, filter, update, options.FindOneAndUpdate().SetReturnDocument(options.After)).Decode(&task)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, status.Errorf(codes.NotFound, "no timer of %s is running on task %s", req.User, req.TaskId)
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to stop timer: %v", err)
+	}
+	return computeFields(&task), nil
+}
+
+// AddWorklog logs a manual worklog entry on the task. The entry's user defaults to the caller and its date to now.
+func (s *server) AddWorklog(ctx context.Context, req *pb.WorklogRequest) (*pb.Task, error) {
+	if err := s.requireTaskAccess(ctx, req.TaskId, writeAccess); err != nil {
+		return nil, err
+	}
+	if err := validator.ValidateWorklog(req.Worklog); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	worklog := req.Worklog
+	user, err := resolveUser(ctx, worklog.User)
+	if err != nil {
+		return nil, err
+	}
+	worklog.User = user
+	worklog.Id = uuid.New().String()
+	if worklog.Date == "" {
+		worklog.Date = time.Now().UTC().Format(time.RFC3339)
+	}
+	var task pb.Task
+	update := bson.M{"$push": bson.M{"worklogs": worklog}}
+	err = s.mongoCol.FindOneAndUpdate(ctx, bson.M{"id": req.TaskId}, update, options.FindOneAndUpdate().SetReturnDocument(options.After)).Decode(&task)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, status.Errorf(codes.NotFound, "task with id %s not found", req.TaskId)
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to add worklog: %v", err)
+	}
+	return computeFields(&task), nil
+}
+
+// DeleteWorklog removes a worklog entry from the task.
+func (s *server) DeleteWorklog(ctx context.Context, req *pb.WorklogID) (*pb.Task, error) {
+	if err := s.requireTaskAccess(ctx, req.TaskId, writeAccess); err != nil {
+		return nil, err
+	}
+	filter := bson.M{"id": req.TaskId, "worklogs.id": req.WorklogId}
+	update := bson.M{"$pull": bson.M{"worklogs": bson.M{"id": req.WorklogId}}}
+	var task pb.Task
+	err := s.mongoCol.FindOneAndUpdate(ctx, filter, update, options.FindOneAndUpdate().SetReturnDocument(options.After)).Decode(&task)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, status.Errorf(codes.NotFound, "worklog %s of task %s not found", req.WorklogId, req.TaskId)
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to delete worklog: %v", err)
+	}
+	return computeFields(&task), nil
+}
+
+// GetTimeReport aggregates the worklogs dated within the requested range by user, label or task.
+// Worklogs of tasks with several labels count towards each of them; unlabeled tasks are grouped under "".
+func (s *server) GetTimeReport(ctx context.Context, req *pb.TimeReportRequest) (*pb.TimeReport, error) {
+	from, err := time.Parse(time.RFC3339, req.From)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "from must be an RFC 3339 timestamp")
+	}
+	to, err := time.Parse(time.RFC3339, req.To)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "to must be an RFC 3339 timestamp")
+	}
+	// worklog dates are stored in UTC, so they compare as strings
+	dateRange := bson.M{"$gte": from.UTC().Format(time.RFC3339), "$lt": to.UTC().Format(time.RFC3339)}
+
+	visible, err := aclFilter(ctx, readAccess)
+	if err != nil {
+		return nil, err
+	}
+	pipeline := mongo.Pipeline{
+		// only report on tasks the caller may see
+		{{Key: "$match", Value: visible}},
+		{{Key: "$match", Value: bson.M{"worklogs.date": dateRange}}},
+		{{Key: "$unwind", Value: "$worklogs"}},
+		{{Key: "$match", Value: bson.M{"worklogs.date": dateRange}}},
+	}
+	group := bson.M{
+		"total":   bson.M{"$sum": "$worklogs.durationseconds"},
+		"entries": bson.M{"$sum": 1},
+	}
+	switch req.GroupBy {
+	case "user":
+		group["_id"] = "$worklogs.user"
+	case "label":
+		pipeline = append(pipeline, bson.D{{Key: "$unwind", Value: bson.M{"path": "$labels", "preserveNullAndEmptyArrays": true}}})
+		group["_id"] = bson.M{"$ifNull": bson.A{"$labels", ""}}
+	case "task":
+		group["_id"] = "$id"
+		group["title"] = bson.M{"$first": "$title"}
+		group["estimate"] = bson.M{"$first": "$estimateseconds"}
+	default:
+		return nil, status.Error(codes.InvalidArgument, "group_by must be user, label or task")
+	}
+	pipeline = append(pipeline,
+		bson.D{{Key: "$group", Value: group}},
+		bson.D{{Key: "$sort", Value: bson.D{{Key: "total", Value: -1}, {Key: "_id", Value: 1}}}},
+	)
+
+	cursor, err := s.mongoCol.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to aggregate worklogs: %v", err)
+	}
+	var rows []struct {
+		Key      string `bson:"_id"`
+		Total    int64  `bson:"total"`
+		Entries  int64  `bson:"entries"`
+		Title    string `bson:"title"`
+		Estimate int64  `bson:"estimate"`
+	}
+	if err := cursor.All(ctx, &rows); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to aggregate worklogs: %v", err)
+	}
+	report := &pb.TimeReport{From: req.From, To: req.To, GroupBy: req.GroupBy, Rows: []*pb.TimeReportRow{}}
+	for _, row := range rows {
+		report.Rows = append(report.Rows, &pb.TimeReportRow{
+			Key:             row.Key,
+			TotalSeconds:    row.Total,
+			Entries:         row.Entries,
+			Title:           row.Title,
+			EstimateSeconds: row.Estimate,
+		})
+	}
+	return report, nil
+}
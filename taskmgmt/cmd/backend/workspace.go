@@ -0,0 +1,177 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Cohen-J-Omer/k8-task-mgmt-system/taskmgmt/internal/identity"
+	"github.com/Cohen-J-Omer/k8-task-mgmt-system/taskmgmt/internal/rbac"
+	"github.com/Cohen-J-Omer/k8-task-mgmt-system/taskmgmt/internal/validator"
+	pb "github.com/Cohen-J-Omer/k8-task-mgmt-system/taskmgmt/proto"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// implements gRPC's WorkspaceServiceServer interface
+// workspaceServer manages the workspaces tenants' data is kept apart in. Only operators may manage them.
+type workspaceServer struct {
+	pb.UnimplementedWorkspaceServiceServer
+	mongoCol *mongo.Collection // collection handler for the "workspaces" MongoDB collection
+	// the collections of the data tenants create, such as users and tasks, which must be removed before
+	// their workspace is deleted
+	ownedCols []*scopedCollection
+	// the collections of the data kept about the former, such as task history, deleted with the workspace
+	derivedCols []*scopedCollection
+}
+
+// newWorkspaceServer creates a workspaceServer, ensuring workspace IDs are unique and the default workspace exists.
+func newWorkspaceServer(ctx context.Context, workspaces *mongo.Collection, owned, derived []*scopedCollection) (*workspaceServer, error) {
+	_, err := workspaces.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "id", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	})
+	if err != nil {
+		return nil, err
+	}
+	_, err = workspaces.UpdateOne(ctx,
+		bson.M{"id": identity.DefaultWorkspace},
+		bson.M{"$setOnInsert": bson.M{"name": "Default", "createdat": time.Now().UTC().Format(time.RFC3339)}},
+		options.Update().SetUpsert(true))
+	if err != nil {
+		return nil, err
+	}
+	return &workspaceServer{mongoCol: workspaces, ownedCols: owned, derivedCols: derived}, nil
+}
+
+// requireOperator fails unless the caller administers the whole deployment.
+func requireOperator(ctx context.Context) error {
+	p, ok := identity.FromIncomingContext(ctx)
+	if !ok {
+		return status.Error(codes.Unauthenticated, "caller is not identified")
+	}
+	if !rbac.Operator(p) {
+		return status.Errorf(codes.PermissionDenied, "only admins of the %s workspace may administer the deployment", identity.DefaultWorkspace)
+	}
+	return nil
+}
+
+// requireWorkspaceExists fails with FailedPrecondition unless the workspace exists.
+func requireWorkspaceExists(ctx context.Context, workspaces *mongo.Collection, id string) error {
+	if err := workspaces.FindOne(ctx, bson.M{"id": id}).Err(); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return status.Errorf(codes.FailedPrecondition, "workspace %s does not exist", id)
+		}
+		return status.Errorf(codes.Internal, "failed to get workspace: %v", err)
+	}
+	return nil
+}
+
+// CreateWorkspace stores a new workspace, failing if its ID is taken.
+func (s *workspaceServer) CreateWorkspace(ctx context.Context, req *pb.Workspace) (*pb.Workspace, error) {
+	if err := requireOperator(ctx); err != nil {
+		return nil, err
+	}
+	if err := validator.ValidateWorkspace(req); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	req.CreatedAt = time.Now().UTC().Format(time.RFC3339)
+	if _, err := s.mongoCol.InsertOne(ctx, req); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return nil, status.Errorf(codes.AlreadyExists, "workspace %s already exists", req.Id)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to create workspace: %v", err)
+	}
+	return req, nil
+}
+
+// GetWorkspace retrieves a workspace by its ID.
+func (s *workspaceServer) GetWorkspace(ctx context.Context, req *pb.WorkspaceID) (*pb.Workspace, error) {
+	if err := requireOperator(ctx); err != nil {
+		return nil, err
+	}
+	var ws pb.Workspace
+	if err := s.mongoCol.FindOne(ctx, bson.M{"id": req.Id}).Decode(&ws); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, status.Errorf(codes.NotFound, "workspace %s not found", req.Id)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get workspace: %v", err)
+	}
+	return &ws, nil
+}
+
+// GetWorkspaces retrieves all workspaces ordered by ID.
+func (s *workspaceServer) GetWorkspaces(ctx context.Context, _ *pb.Empty) (*pb.WorkspaceList, error) {
+	if err := requireOperator(ctx); err != nil {
+		return nil, err
+	}
+	cursor, err := s.mongoCol.Find(ctx, bson.M{}, options.Find().SetSort(bson.D{{Key: "id", Value: 1}}))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list workspaces: %v", err)
+	}
+	list := &pb.WorkspaceList{Workspaces: []*pb.Workspace{}}
+	if err := cursor.All(ctx, &list.Workspaces); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list workspaces: %v", err)
+	}
+	return list, nil
+}
+
+// UpdateWorkspace renames a workspace.
+func (s *workspaceServer) UpdateWorkspace(ctx context.Context, req *pb.Workspace) (*pb.Workspace, error) {
+	if err := requireOperator(ctx); err != nil {
+		return nil, err
+	}
+	if err := validator.ValidateWorkspace(req); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	var ws pb.Workspace
+	update := bson.M{"$set": bson.M{"name": req.Name}}
+	err := s.mongoCol.FindOneAndUpdate(ctx, bson.M{"id": req.Id}, update, options.FindOneAndUpdate().SetReturnDocument(options.After)).Decode(&ws)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, status.Errorf(codes.NotFound, "workspace %s not found", req.Id)
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to update workspace: %v", err)
+	}
+	return &ws, nil
+}
+
+// DeleteWorkspace deletes an empty workspace. The data its tenant created, such as users, API keys,
+// projects and tasks, must be removed first, so deleting a workspace never silently drops a tenant's
+// data, while the data derived from it, such as task history, is deleted along with the workspace, so
+// it doesn't leak into a workspace later created with the same ID. The default workspace can't be deleted.
+func (s *workspaceServer) DeleteWorkspace(ctx context.Context, req *pb.WorkspaceID) (*pb.Workspace, error) {
+	if err := requireOperator(ctx); err != nil {
+		return nil, err
+	}
+	if req.Id == identity.DefaultWorkspace {
+		return nil, status.Errorf(codes.FailedPrecondition, "the %s workspace can't be deleted", identity.DefaultWorkspace)
+	}
+	// the check spans another workspace than the caller's
+	for _, col := range s.ownedCols {
+		n, err := col.Unscoped().CountDocuments(ctx, bson.M{workspaceField: req.Id}, options.Count().SetLimit(1))
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to delete workspace: %v", err)
+		}
+		if n > 0 {
+			return nil, status.Errorf(codes.FailedPrecondition, "workspace %s still has %s", req.Id, col.Unscoped().Name())
+		}
+	}
+	var ws pb.Workspace
+	if err := s.mongoCol.FindOneAndDelete(ctx, bson.M{"id": req.Id}).Decode(&ws); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, status.Errorf(codes.NotFound, "workspace %s not found", req.Id)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to delete workspace: %v", err)
+	}
+	for _, col := range s.derivedCols {
+		if _, err := col.Unscoped().DeleteMany(ctx, bson.M{workspaceField: req.Id}); err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to delete the workspace's %s: %v", col.Unscoped().Name(), err)
+		}
+	}
+	return &ws, nil
+}
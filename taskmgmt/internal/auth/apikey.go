@@ -0,0 +1,136 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Cohen-J-Omer/k8-task-mgmt-system/taskmgmt/internal/identity"
+	pb "github.com/Cohen-J-Omer/k8-task-mgmt-system/taskmgmt/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// apiKeyScheme starts every API key, so keys are recognizable e.g. by secret scanners.
+const apiKeyScheme = "tm_"
+
+// GenerateAPIKey returns a new random API key of the form tm_<prefix>_<secret>
+// along with its prefix (tm_<prefix>), which identifies the key without revealing it.
+func GenerateAPIKey() (key, prefix string, err error) {
+	id := make([]byte, 4)
+	secret := make([]byte, 32)
+	if _, err := rand.Read(id); err != nil {
+		return "", "", err
+	}
+	if _, err := rand.Read(secret); err != nil {
+		return "", "", err
+	}
+	prefix = apiKeyScheme + hex.EncodeToString(id)
+	return prefix + "_" + base64.RawURLEncoding.EncodeToString(secret), prefix, nil
+}
+
+// APIKeyPrefix returns the prefix of an API key, reporting false if the token is not shaped like one.
+func APIKeyPrefix(key string) (string, bool) {
+	if !strings.HasPrefix(key, apiKeyScheme) {
+		return "", false
+	}
+	i := strings.Index(key[len(apiKeyScheme):], "_")
+	if i <= 0 {
+		return "", false
+	}
+	return key[:len(apiKeyScheme)+i], true
+}
+
+// HashAPIKey returns the hex encoded SHA-256 hash API keys are stored as.
+// API keys are long random strings, so a fast unsalted hash does not make them guessable.
+func HashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// maxCachedAPIKeys bounds the cache, which is flushed once it is full.
+const maxCachedAPIKeys = 10000
+
+// APIKeys resolves API keys through the backend's AuthService, caching accepted keys
+// for a short while so most requests don't cost a backend round trip.
+// Revoking or expiring a key therefore takes up to the cache TTL to take effect.
+type APIKeys struct {
+	client pb.AuthServiceClient
+	ttl    time.Duration
+
+	mu    sync.Mutex
+	cache map[string]cachedPrincipal // keyed by the key's hash
+}
+
+type cachedPrincipal struct {
+	principal *identity.Principal
+	expires   time.Time
+}
+
+func NewAPIKeys(client pb.AuthServiceClient, ttl time.Duration) *APIKeys {
+	return &APIKeys{client: client, ttl: ttl, cache: map[string]cachedPrincipal{}}
+}
+
+// Authenticate implements Authenticator.
+func (a *APIKeys) Authenticate(ctx context.Context, token string) (*identity.Principal, error) {
+	if _, ok := APIKeyPrefix(token); !ok {
+		return nil, ErrInvalidCredentials
+	}
+	hash := HashAPIKey(token)
+	a.mu.Lock()
+	cached, ok := a.cache[hash]
+	a.mu.Unlock()
+	if ok && time.Now().Before(cached.expires) {
+		return cached.principal, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 2*time.Second)
+	defer cancel()
+	resp, err := a.client.AuthenticateAPIKey(ctx, &pb.APIKeySecret{Key: token})
+	if status.Code(err) == codes.Unauthenticated {
+		return nil, ErrInvalidCredentials
+	}
+	if err != nil {
+		return nil, err
+	}
+	p := &identity.Principal{UserID: resp.UserId, TeamIDs: resp.TeamIds, Roles: resp.Roles, WorkspaceID: resp.WorkspaceId, APIKeyID: resp.ApiKeyId}
+	if a.ttl > 0 {
+		a.mu.Lock()
+		if len(a.cache) >= maxCachedAPIKeys {
+			a.cache = map[string]cachedPrincipal{}
+		}
+		a.cache[hash] = cachedPrincipal{principal: p, expires: time.Now().Add(a.ttl)}
+		a.mu.Unlock()
+	}
+	return p, nil
+}
+
+// ErrUnknownUser is returned when resolving a user who doesn't exist in the caller's workspace.
+var ErrUnknownUser = errors.New("unknown user")
+
+// Users resolves the users the bootstrap admin key acts on behalf of through the backend's AuthService.
+type Users struct {
+	client pb.AuthServiceClient
+}
+
+func NewUsers(client pb.AuthServiceClient) *Users {
+	return &Users{client: client}
+}
+
+// Resolve implements UserResolver.
+func (u *Users) Resolve(ctx context.Context, userID string) (*identity.Principal, error) {
+	resp, err := u.client.ResolveUser(ctx, &pb.UserID{Id: userID})
+	if status.Code(err) == codes.NotFound {
+		return nil, ErrUnknownUser
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &identity.Principal{UserID: resp.UserId, TeamIDs: resp.TeamIds, Roles: resp.Roles, WorkspaceID: resp.WorkspaceId}, nil
+}
@@ -0,0 +1,39 @@
+package auth
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Cohen-J-Omer/k8-task-mgmt-system/taskmgmt/internal/identity"
+)
+
+// ErrInvalidCredentials is returned when a presented token is not accepted by an authenticator.
+var ErrInvalidCredentials = errors.New("invalid credentials")
+
+// Authenticator resolves the bearer token of a request to the calling principal.
+// It returns ErrInvalidCredentials for tokens it does not accept, and other errors
+// when it could not decide, e.g. because the backend is unreachable.
+type Authenticator interface {
+	Authenticate(ctx context.Context, token string) (*identity.Principal, error)
+}
+
+// UserResolver resolves a user of the workspace of the principal in ctx to the user's own principal,
+// returning ErrUnknownUser if the workspace has no such user.
+type UserResolver interface {
+	Resolve(ctx context.Context, userID string) (*identity.Principal, error)
+}
+
+// Chain tries each authenticator in order, accepting the first principal resolved.
+type Chain []Authenticator
+
+// Authenticate implements Authenticator.
+func (c Chain) Authenticate(ctx context.Context, token string) (*identity.Principal, error) {
+	for _, a := range c {
+		p, err := a.Authenticate(ctx, token)
+		if errors.Is(err, ErrInvalidCredentials) {
+			continue
+		}
+		return p, err
+	}
+	return nil, ErrInvalidCredentials
+}
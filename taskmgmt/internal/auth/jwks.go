@@ -0,0 +1,190 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// minJWKSRefresh rate limits the refreshes triggered by tokens signed with unknown keys.
+const minJWKSRefresh = time.Minute
+
+// JWKS is a JSON Web Key Set loaded from a local file or an http(s) URL.
+// Keys are cached and reloaded every refresh interval, and early when a token names an
+// unknown key ID, so the identity provider can rotate its keys without a restart.
+type JWKS struct {
+	source  string
+	refresh time.Duration
+	client  *http.Client
+
+	mu      sync.Mutex
+	keys    map[string]crypto.PublicKey // by key ID
+	fetched time.Time
+}
+
+// NewJWKS loads the key set from source, a file path or an http(s) URL.
+func NewJWKS(ctx context.Context, source string, refresh time.Duration) (*JWKS, error) {
+	j := &JWKS{source: source, refresh: refresh, client: &http.Client{Timeout: 5 * time.Second}}
+	if err := j.load(ctx); err != nil {
+		return nil, err
+	}
+	return j, nil
+}
+
+// Key returns the public key with the given ID, reloading the key set if it is stale or misses the key.
+// If reloading fails, the previously loaded keys keep being used.
+func (j *JWKS) Key(ctx context.Context, kid string) (crypto.PublicKey, error) {
+	j.mu.Lock()
+	key, ok := j.keys[kid]
+	age := time.Since(j.fetched)
+	j.mu.Unlock()
+	if (ok && age < j.refresh) || (!ok && age < minJWKSRefresh) {
+		if !ok {
+			return nil, fmt.Errorf("unknown key ID %q", kid)
+		}
+		return key, nil
+	}
+	if err := j.load(ctx); err != nil && !ok {
+		return nil, err
+	}
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if key, ok := j.keys[kid]; ok {
+		return key, nil
+	}
+	return nil, fmt.Errorf("unknown key ID %q", kid)
+}
+
+// load fetches and parses the key set, replacing the cached keys.
+func (j *JWKS) load(ctx context.Context) error {
+	data, err := j.fetch(ctx)
+	// even a failed attempt delays the next one, so an unreachable provider isn't hammered
+	j.mu.Lock()
+	j.fetched = time.Now()
+	j.mu.Unlock()
+	if err != nil {
+		return fmt.Errorf("failed to load JWKS from %s: %w", j.source, err)
+	}
+	keys, err := parseJWKS(data)
+	if err != nil {
+		return fmt.Errorf("failed to parse JWKS from %s: %w", j.source, err)
+	}
+	j.mu.Lock()
+	j.keys = keys
+	j.mu.Unlock()
+	return nil
+}
+
+func (j *JWKS) fetch(ctx context.Context) ([]byte, error) {
+	if !strings.HasPrefix(j.source, "http://") && !strings.HasPrefix(j.source, "https://") {
+		return os.ReadFile(j.source)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, j.source, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := j.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+}
+
+// jwk holds the members of RSA and EC JSON Web Keys (RFC 7517/7518) used for verifying signatures.
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// parseJWKS parses the RSA and EC signing keys of a key set, skipping keys of other types or uses.
+func parseJWKS(data []byte) (map[string]crypto.PublicKey, error) {
+	var set struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, err
+	}
+	keys := map[string]crypto.PublicKey{}
+	for _, k := range set.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+		var key crypto.PublicKey
+		var err error
+		switch k.Kty {
+		case "RSA":
+			key, err = k.rsaKey()
+		case "EC":
+			key, err = k.ecKey()
+		default:
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("key %q: %w", k.Kid, err)
+		}
+		keys[k.Kid] = key
+	}
+	if len(keys) == 0 {
+		return nil, errors.New("no signing keys found")
+	}
+	return keys, nil
+}
+
+func (k *jwk) rsaKey() (*rsa.PublicKey, error) {
+	n, err := base64.RawURLEncoding.DecodeString(k.N)
+	if err != nil {
+		return nil, errors.New("invalid modulus")
+	}
+	e, err := base64.RawURLEncoding.DecodeString(k.E)
+	if err != nil || len(e) == 0 || len(e) > 4 {
+		return nil, errors.New("invalid exponent")
+	}
+	return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}, nil
+}
+
+func (k *jwk) ecKey() (*ecdsa.PublicKey, error) {
+	var curve elliptic.Curve
+	switch k.Crv {
+	case "P-256":
+		curve = elliptic.P256()
+	case "P-384":
+		curve = elliptic.P384()
+	case "P-521":
+		curve = elliptic.P521()
+	default:
+		return nil, fmt.Errorf("unsupported curve %q", k.Crv)
+	}
+	x, errX := base64.RawURLEncoding.DecodeString(k.X)
+	y, errY := base64.RawURLEncoding.DecodeString(k.Y)
+	if errX != nil || errY != nil {
+		return nil, errors.New("invalid coordinates")
+	}
+	key := &ecdsa.PublicKey{Curve: curve, X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}
+	if _, err := key.ECDH(); err != nil {
+		return nil, errors.New("point is not on the curve")
+	}
+	return key, nil
+}
@@ -0,0 +1,235 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// testIdP is an identity provider serving the JWKS of its signing keys over HTTP.
+type testIdP struct {
+	server *httptest.Server
+
+	mu   sync.Mutex
+	keys map[string]crypto.Signer // by key ID
+}
+
+func newTestIdP(t *testing.T) *testIdP {
+	t.Helper()
+	idp := &testIdP{keys: map[string]crypto.Signer{}}
+	idp.server = httptest.NewServer(http.HandlerFunc(idp.serveJWKS))
+	t.Cleanup(idp.server.Close)
+	return idp
+}
+
+// addRSAKey generates an RSA signing key published under the key ID.
+func (idp *testIdP) addRSAKey(t *testing.T, kid string) {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate RSA key: %v", err)
+	}
+	idp.mu.Lock()
+	defer idp.mu.Unlock()
+	idp.keys[kid] = key
+}
+
+// addECKey generates a P-256 signing key published under the key ID.
+func (idp *testIdP) addECKey(t *testing.T, kid string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate EC key: %v", err)
+	}
+	idp.mu.Lock()
+	defer idp.mu.Unlock()
+	idp.keys[kid] = key
+}
+
+func (idp *testIdP) serveJWKS(w http.ResponseWriter, _ *http.Request) {
+	idp.mu.Lock()
+	defer idp.mu.Unlock()
+	b64 := func(i *big.Int) string { return base64.RawURLEncoding.EncodeToString(i.Bytes()) }
+	var set struct {
+		Keys []jwk `json:"keys"`
+	}
+	for kid, key := range idp.keys {
+		switch key := key.Public().(type) {
+		case *rsa.PublicKey:
+			set.Keys = append(set.Keys, jwk{Kty: "RSA", Kid: kid, Use: "sig", N: b64(key.N), E: b64(big.NewInt(int64(key.E)))})
+		case *ecdsa.PublicKey:
+			set.Keys = append(set.Keys, jwk{Kty: "EC", Kid: kid, Use: "sig", Crv: "P-256", X: b64(key.X), Y: b64(key.Y)})
+		}
+	}
+	_ = json.NewEncoder(w).Encode(set)
+}
+
+// sign returns a token of the claims signed by the key with the ID.
+func (idp *testIdP) sign(t *testing.T, kid string, claims jwt.MapClaims) string {
+	t.Helper()
+	idp.mu.Lock()
+	key := idp.keys[kid]
+	idp.mu.Unlock()
+	method := jwt.SigningMethod(jwt.SigningMethodRS256)
+	if _, ok := key.(*ecdsa.PrivateKey); ok {
+		method = jwt.SigningMethodES256
+	}
+	token := jwt.NewWithClaims(method, claims)
+	token.Header["kid"] = kid
+	signed, err := token.SignedString(key)
+	if err != nil {
+		t.Fatalf("failed to sign token: %v", err)
+	}
+	return signed
+}
+
+// validClaims returns the claims of a token valid for the test configuration.
+func validClaims() jwt.MapClaims {
+	return jwt.MapClaims{
+		"iss":       "https://idp.test",
+		"aud":       "taskmgmt",
+		"sub":       "user-1",
+		"exp":       time.Now().Add(time.Hour).Unix(),
+		"workspace": "acme",
+		"groups":    []string{"team-1", "team-2"},
+		"realm_access": map[string]interface{}{
+			"roles": []string{"member"},
+		},
+	}
+}
+
+func newTestJWT(t *testing.T, idp *testIdP, cfg JWTConfig) *JWT {
+	t.Helper()
+	keys, err := NewJWKS(context.Background(), idp.server.URL, time.Hour)
+	if err != nil {
+		t.Fatalf("NewJWKS: %v", err)
+	}
+	cfg.Issuer, cfg.Audience, cfg.RolesClaim = "https://idp.test", "taskmgmt", "realm_access.roles"
+	return NewJWT(keys, cfg)
+}
+
+func TestJWTAuthenticate(t *testing.T) {
+	idp := newTestIdP(t)
+	idp.addRSAKey(t, "rsa-1")
+	idp.addECKey(t, "ec-1")
+	a := newTestJWT(t, idp, JWTConfig{})
+
+	for _, kid := range []string{"rsa-1", "ec-1"} {
+		t.Run(kid, func(t *testing.T) {
+			p, err := a.Authenticate(context.Background(), idp.sign(t, kid, validClaims()))
+			if err != nil {
+				t.Fatalf("Authenticate: %v", err)
+			}
+			if p.UserID != "user-1" || p.WorkspaceID != "acme" {
+				t.Errorf("got user %q in workspace %q, want user-1 in acme", p.UserID, p.WorkspaceID)
+			}
+			if !slices.Equal(p.Roles, []string{"member"}) || !slices.Equal(p.TeamIDs, []string{"team-1", "team-2"}) {
+				t.Errorf("got roles %v and teams %v", p.Roles, p.TeamIDs)
+			}
+		})
+	}
+}
+
+func TestJWTRejectsInvalidTokens(t *testing.T) {
+	idp := newTestIdP(t)
+	idp.addRSAKey(t, "rsa-1")
+	a := newTestJWT(t, idp, JWTConfig{})
+
+	// a key the identity provider never published
+	other := newTestIdP(t)
+	other.addRSAKey(t, "rsa-1")
+
+	with := func(name string, value interface{}) jwt.MapClaims {
+		claims := validClaims()
+		if value == nil {
+			delete(claims, name)
+		} else {
+			claims[name] = value
+		}
+		return claims
+	}
+	hmac, err := jwt.NewWithClaims(jwt.SigningMethodHS256, validClaims()).SignedString([]byte("secret"))
+	if err != nil {
+		t.Fatalf("failed to sign token: %v", err)
+	}
+
+	tests := map[string]string{
+		"not a JWT":          "api-key",
+		"expired":            idp.sign(t, "rsa-1", with("exp", time.Now().Add(-time.Hour).Unix())),
+		"without expiry":     idp.sign(t, "rsa-1", with("exp", nil)),
+		"other issuer":       idp.sign(t, "rsa-1", with("iss", "https://other.test")),
+		"other audience":     idp.sign(t, "rsa-1", with("aud", "other")),
+		"without subject":    idp.sign(t, "rsa-1", with("sub", nil)),
+		"without workspace":  idp.sign(t, "rsa-1", with("workspace", nil)),
+		"unpublished key":    other.sign(t, "rsa-1", validClaims()),
+		"symmetric signing":  hmac,
+		"tampered signature": idp.sign(t, "rsa-1", validClaims()) + "A",
+	}
+	for name, token := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := a.Authenticate(context.Background(), token); !errors.Is(err, ErrInvalidCredentials) {
+				t.Errorf("Authenticate returned %v, want ErrInvalidCredentials", err)
+			}
+		})
+	}
+}
+
+func TestJWTDefaultWorkspace(t *testing.T) {
+	idp := newTestIdP(t)
+	idp.addRSAKey(t, "rsa-1")
+	a := newTestJWT(t, idp, JWTConfig{DefaultWorkspace: "fallback"})
+
+	claims := validClaims()
+	delete(claims, "workspace")
+	p, err := a.Authenticate(context.Background(), idp.sign(t, "rsa-1", claims))
+	if err != nil {
+		t.Fatalf("Authenticate: %v", err)
+	}
+	if p.WorkspaceID != "fallback" {
+		t.Errorf("got workspace %q, want the configured default", p.WorkspaceID)
+	}
+
+	// the claim still wins when present
+	p, err = a.Authenticate(context.Background(), idp.sign(t, "rsa-1", validClaims()))
+	if err != nil {
+		t.Fatalf("Authenticate: %v", err)
+	}
+	if p.WorkspaceID != "acme" {
+		t.Errorf("got workspace %q, want the claim's", p.WorkspaceID)
+	}
+}
+
+func TestJWKSPicksUpRotatedKeys(t *testing.T) {
+	idp := newTestIdP(t)
+	idp.addRSAKey(t, "rsa-1")
+	a := newTestJWT(t, idp, JWTConfig{})
+
+	idp.addECKey(t, "ec-2")
+	token := idp.sign(t, "ec-2", validClaims())
+	// unknown keys don't reload the set more often than minJWKSRefresh
+	if _, err := a.Authenticate(context.Background(), token); err == nil {
+		t.Fatal("key was reloaded before minJWKSRefresh passed")
+	}
+
+	a.keys.mu.Lock()
+	a.keys.fetched = time.Now().Add(-minJWKSRefresh)
+	a.keys.mu.Unlock()
+	if _, err := a.Authenticate(context.Background(), token); err != nil {
+		t.Errorf("Authenticate with the rotated key: %v", err)
+	}
+}
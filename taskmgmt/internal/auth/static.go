@@ -0,0 +1,30 @@
+package auth
+
+import (
+	"context"
+	"crypto/subtle"
+
+	"github.com/Cohen-J-Omer/k8-task-mgmt-system/taskmgmt/internal/identity"
+	"github.com/Cohen-J-Omer/k8-task-mgmt-system/taskmgmt/internal/rbac"
+)
+
+// BootstrapUserID is the user ID of the bootstrap admin key's principal.
+const BootstrapUserID = "admin"
+
+// StaticToken accepts a single preconfigured token, the bootstrap admin key used
+// to create the first workspaces, users and API keys.
+type StaticToken struct {
+	token string
+}
+
+func NewStaticToken(token string) *StaticToken {
+	return &StaticToken{token: token}
+}
+
+// Authenticate implements Authenticator.
+func (s *StaticToken) Authenticate(_ context.Context, token string) (*identity.Principal, error) {
+	if s.token == "" || subtle.ConstantTimeCompare([]byte(token), []byte(s.token)) != 1 {
+		return nil, ErrInvalidCredentials
+	}
+	return &identity.Principal{UserID: BootstrapUserID, Roles: []string{rbac.Admin}, WorkspaceID: identity.DefaultWorkspace, Bootstrap: true}, nil
+}
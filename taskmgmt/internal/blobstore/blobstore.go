@@ -0,0 +1,23 @@
+package blobstore
+
+import (
+	"context"
+	"errors"
+	"io"
+)
+
+// ErrNotFound is returned when a blob does not exist in the store.
+var ErrNotFound = errors.New("blob not found")
+
+// BlobStore stores opaque binary content (e.g. task attachments) under a string key.
+// LocalStore keeps blobs on the local filesystem; S3-compatible object stores can
+// implement the same interface and be selected by the backend's BLOB_STORE setting.
+type BlobStore interface {
+	// Put stores the content read from r under key, replacing any existing blob.
+	Put(ctx context.Context, key string, r io.Reader) error
+	// Get opens the blob stored under key starting at offset.
+	// length <= 0 reads until the end of the blob.
+	Get(ctx context.Context, key string, offset, length int64) (io.ReadCloser, error)
+	// Delete removes the blob stored under key. Deleting a missing blob is not an error.
+	Delete(ctx context.Context, key string) error
+}
@@ -3,6 +3,7 @@ package config
 import (
 	"log"
 	"os"
+	"strconv"
 	"strings"
 
 	"github.com/joho/godotenv"
@@ -22,3 +23,40 @@ func LoadDotenvIfDebug() bool {
 	}
 	return false
 }
+
+// GetEnv returns the value of the environment variable key, or def if it is unset or empty.
+func GetEnv(key, def string) string {
+	if v, ok := os.LookupEnv(key); ok && v != "" {
+		return v
+	}
+	return def
+}
+
+// GetEnvInt64 returns the environment variable key parsed as an int64, or def if it is unset or empty.
+// exits program if the value is not a valid integer
+func GetEnvInt64(key string, def int64) int64 {
+	v, ok := os.LookupEnv(key)
+	if !ok || v == "" {
+		return def
+	}
+	n, err := strconv.ParseInt(v, 10, 64)
+	if err != nil {
+		log.Fatalf("Environment variable %s must be an integer: %v", key, err)
+	}
+	return n
+}
+
+// GetEnvList returns the comma separated environment variable key as a slice, or def if it is unset or empty.
+func GetEnvList(key string, def []string) []string {
+	v, ok := os.LookupEnv(key)
+	if !ok || v == "" {
+		return def
+	}
+	var list []string
+	for _, item := range strings.Split(v, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
@@ -0,0 +1,41 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/Cohen-J-Omer/k8-task-mgmt-system/taskmgmt/internal/validator"
+	pb "github.com/Cohen-J-Omer/k8-task-mgmt-system/taskmgmt/proto"
+	"github.com/gin-gonic/gin"
+)
+
+// SetTaskACL replaces a task's access control list. Only the task's owner and admins may change it.
+func (h *TaskHandler) SetTaskACL(c *gin.Context) {
+	var acl pb.TaskACL
+	// bind the JSON body to the ACL struct
+	if err := c.ShouldBindJSON(&acl); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err := validator.ValidateTaskACL(&acl); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	ctx := c.Request.Context()
+	task, err := h.client.SetTaskACL(ctx, &pb.TaskACLRequest{TaskId: c.Param("id"), Acl: &acl})
+	if err != nil {
+		respondRPCError(c, err, "failed to set task access control list")
+		return
+	}
+	c.JSON(http.StatusOK, task)
+}
+
+// GetTaskAccess lists everyone who can access a task and how, for auditing.
+func (h *TaskHandler) GetTaskAccess(c *gin.Context) {
+	ctx := c.Request.Context()
+	access, err := h.client.GetTaskAccess(ctx, &pb.TaskID{Id: c.Param("id")})
+	if err != nil {
+		respondRPCError(c, err, "failed to get task access")
+		return
+	}
+	c.JSON(http.StatusOK, access)
+}
@@ -0,0 +1,54 @@
+package handler
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/Cohen-J-Omer/k8-task-mgmt-system/taskmgmt/internal/logging"
+	pb "github.com/Cohen-J-Omer/k8-task-mgmt-system/taskmgmt/proto"
+	"github.com/gin-gonic/gin"
+)
+
+// AdminHandler handles API HTTP requests administering the running services
+// using a gRPC client to communicate with the backend service.
+type AdminHandler struct {
+	client pb.AdminServiceClient
+}
+
+func NewAdminHandler(client pb.AdminServiceClient) *AdminHandler {
+	return &AdminHandler{client: client}
+}
+
+// GetLogLevel returns the log levels of the API and the backend.
+func (h *AdminHandler) GetLogLevel(c *gin.Context) {
+	ctx := c.Request.Context()
+	backend, err := h.client.GetLogLevel(ctx, &pb.Empty{})
+	if err != nil {
+		respondRPCError(c, err, "failed to get log level")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"api": logging.Level(), "backend": backend.Level})
+}
+
+// SetLogLevel changes the log level of this API replica and of the backend replica serving the call,
+// until they restart or their LOG_LEVEL_FILE changes. The backend, which only lets operators change it,
+// is changed first. Every replica follows LOG_LEVEL_FILE instead.
+func (h *AdminHandler) SetLogLevel(c *gin.Context) {
+	var req pb.LogLevel
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	ctx := c.Request.Context()
+	backend, err := h.client.SetLogLevel(ctx, &req)
+	if err != nil {
+		respondRPCError(c, err, "failed to set log level")
+		return
+	}
+	if err := logging.SetLevel(req.Level); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	slog.WarnContext(c.Request.Context(), "log level changed", "level", logging.Level())
+	c.JSON(http.StatusOK, gin.H{"api": logging.Level(), "backend": backend.Level})
+}
@@ -0,0 +1,62 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/Cohen-J-Omer/k8-task-mgmt-system/taskmgmt/internal/validator"
+	pb "github.com/Cohen-J-Omer/k8-task-mgmt-system/taskmgmt/proto"
+	"github.com/gin-gonic/gin"
+)
+
+// APIKeyHandler handles API HTTP requests for managing API keys
+// using a gRPC client to communicate with the backend service.
+type APIKeyHandler struct {
+	client pb.AuthServiceClient
+}
+
+func NewAPIKeyHandler(client pb.AuthServiceClient) *APIKeyHandler {
+	return &APIKeyHandler{client: client}
+}
+
+// CreateKey issues an API key, expecting {"user_id": "...", "name": "...", "expires_at": "..."}.
+// The response holds the plaintext key, which can't be retrieved again.
+func (h *APIKeyHandler) CreateKey(c *gin.Context) {
+	var req pb.APIKey
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err := validator.ValidateAPIKey(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	ctx := c.Request.Context()
+	key, err := h.client.CreateAPIKey(ctx, &req)
+	if err != nil {
+		respondRPCError(c, err, "failed to create API key")
+		return
+	}
+	c.JSON(http.StatusCreated, key)
+}
+
+// GetKeys lists API keys without their secrets, optionally filtered by the "user_id" query parameter.
+func (h *APIKeyHandler) GetKeys(c *gin.Context) {
+	ctx := c.Request.Context()
+	list, err := h.client.GetAPIKeys(ctx, &pb.APIKeyFilter{UserId: c.Query("user_id")})
+	if err != nil {
+		respondRPCError(c, err, "failed to list API keys")
+		return
+	}
+	c.JSON(http.StatusOK, list)
+}
+
+// RevokeKey revokes an API key by its ID.
+func (h *APIKeyHandler) RevokeKey(c *gin.Context) {
+	ctx := c.Request.Context()
+	key, err := h.client.RevokeAPIKey(ctx, &pb.APIKeyID{Id: c.Param("id")})
+	if err != nil {
+		respondRPCError(c, err, "failed to revoke API key")
+		return
+	}
+	c.JSON(http.StatusOK, key)
+}
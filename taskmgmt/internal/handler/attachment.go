@@ -0,0 +1,219 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/Cohen-J-Omer/k8-task-mgmt-system/taskmgmt/internal/validator"
+	pb "github.com/Cohen-J-Omer/k8-task-mgmt-system/taskmgmt/proto"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// attachmentChunkSize is the size of the content chunks streamed to the backend.
+	attachmentChunkSize = 64 * 1024
+	// multipartOverhead leaves room for the multipart boundaries and headers on top of the file itself.
+	multipartOverhead = 64 * 1024
+)
+
+// UploadAttachment handles the multipart upload of a file attached to a task.
+// The file is expected in the "file" form field.
+func (h *TaskHandler) UploadAttachment(c *gin.Context) {
+	id := c.Param("id")
+	// reject oversized bodies before they are buffered
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, h.attachmentLimits.MaxBytes+multipartOverhead)
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		var maxErr *http.MaxBytesError
+		if errors.As(err, &maxErr) {
+			c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": fmt.Sprintf("%v of %d bytes", validator.ErrAttachmentTooLarge, h.attachmentLimits.MaxBytes)})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": "multipart form with a file field is required"})
+		return
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to read uploaded file"})
+		return
+	}
+	defer file.Close()
+
+	// sniff the content type from the content rather than trusting the client's header
+	head := make([]byte, 512)
+	n, err := io.ReadFull(file, head)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to read uploaded file"})
+		return
+	}
+	head = head[:n]
+	contentType := http.DetectContentType(head)
+	name := filepath.Base(fileHeader.Filename)
+	if err := validator.ValidateAttachment(name, fileHeader.Size, contentType, h.attachmentLimits); err != nil {
+		switch {
+		case errors.Is(err, validator.ErrAttachmentTooLarge):
+			c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": err.Error()})
+		case errors.Is(err, validator.ErrAttachmentType):
+			c.JSON(http.StatusUnsupportedMediaType, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	// the route has no deadline, as uploading large files may take longer
+	stream, err := h.client.UploadAttachment(c.Request.Context())
+	if err != nil {
+		respondRPCError(c, err, "failed to upload attachment")
+		return
+	}
+	chunk := &pb.AttachmentChunk{
+		TaskId:   id,
+		Metadata: &pb.Attachment{Name: name, ContentType: contentType},
+		Data:     head,
+	}
+	for {
+		// a send error means the backend aborted the stream, its status is reported by CloseAndRecv
+		if err := stream.Send(chunk); err != nil {
+			break
+		}
+		// a sent message must not be modified, e.g. as stats handlers may still read it, so each chunk
+		// gets its own buffer
+		buf := make([]byte, attachmentChunkSize)
+		n, err := file.Read(buf)
+		if n > 0 {
+			chunk = &pb.AttachmentChunk{Data: buf[:n]}
+			continue
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to read uploaded file"})
+			return
+		}
+	}
+	att, err := stream.CloseAndRecv()
+	if err != nil {
+		respondRPCError(c, err, "failed to upload attachment")
+		return
+	}
+	c.JSON(http.StatusCreated, att)
+}
+
+// DownloadAttachment streams an attachment's content, honoring a single byte range in the Range header.
+func (h *TaskHandler) DownloadAttachment(c *gin.Context) {
+	id, attachmentID := c.Param("id"), c.Param("attachment_id")
+	ctx := c.Request.Context()
+	task, err := h.client.GetTask(ctx, &pb.TaskID{Id: id})
+	if err != nil {
+		respondRPCError(c, err, "failed to get attachment")
+		return
+	}
+	var att *pb.Attachment
+	for _, a := range task.Attachments {
+		if a.Id == attachmentID {
+			att = a
+		}
+	}
+	if att == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": fmt.Sprintf("attachment %s of task %s not found", attachmentID, id)})
+		return
+	}
+
+	offset, length, partial, ok := parseByteRange(c.GetHeader("Range"), att.Size)
+	if !ok {
+		c.Header("Content-Range", fmt.Sprintf("bytes */%d", att.Size))
+		c.JSON(http.StatusRequestedRangeNotSatisfiable, gin.H{"error": "requested range not satisfiable"})
+		return
+	}
+	stream, err := h.client.DownloadAttachment(ctx, &pb.AttachmentRange{
+		TaskId: id, AttachmentId: attachmentID, Offset: offset, Length: length,
+	})
+	if err != nil {
+		respondRPCError(c, err, "failed to download attachment")
+		return
+	}
+	// receive the first chunk before writing any header, so backend errors still map to a status code
+	chunk, err := stream.Recv()
+	if err != nil && err != io.EOF {
+		respondRPCError(c, err, "failed to download attachment")
+		return
+	}
+
+	c.Header("Accept-Ranges", "bytes")
+	c.Header("Content-Type", att.ContentType)
+	c.Header("Content-Length", strconv.FormatInt(length, 10))
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", att.Name))
+	c.Header("ETag", strconv.Quote(att.Sha256))
+	if partial {
+		c.Header("Content-Range", fmt.Sprintf("bytes %d-%d/%d", offset, offset+length-1, att.Size))
+		c.Status(http.StatusPartialContent)
+	} else {
+		c.Status(http.StatusOK)
+	}
+	for err == nil {
+		if _, werr := c.Writer.Write(chunk.Data); werr != nil {
+			return // client went away
+		}
+		chunk, err = stream.Recv()
+	}
+	if err != io.EOF {
+		// headers are already sent, all that is left is to cut the response short
+		c.Error(err)
+		c.Abort()
+	}
+}
+
+// DeleteAttachment removes an attachment from a task.
+func (h *TaskHandler) DeleteAttachment(c *gin.Context) {
+	req := &pb.AttachmentID{TaskId: c.Param("id"), AttachmentId: c.Param("attachment_id")}
+	att, err := h.client.DeleteAttachment(c.Request.Context(), req)
+	if err != nil {
+		respondRPCError(c, err, "failed to delete attachment")
+		return
+	}
+	c.JSON(http.StatusOK, att)
+}
+
+// parseByteRange resolves a Range header against a content of the given size.
+// Only a single range is supported; a missing or multi-range header selects the whole content.
+// ok is false when the range cannot be satisfied.
+func parseByteRange(header string, size int64) (offset, length int64, partial, ok bool) {
+	spec, found := strings.CutPrefix(header, "bytes=")
+	if !found || strings.Contains(spec, ",") {
+		return 0, size, false, true
+	}
+	startStr, endStr, found := strings.Cut(strings.TrimSpace(spec), "-")
+	if !found {
+		return 0, 0, false, false
+	}
+	if startStr == "" {
+		// suffix range, i.e., the last n bytes
+		n, err := strconv.ParseInt(endStr, 10, 64)
+		// an empty content has no last bytes to send
+		if err != nil || n <= 0 || size == 0 {
+			return 0, 0, false, false
+		}
+		n = min(n, size)
+		return size - n, n, true, true
+	}
+	start, err := strconv.ParseInt(startStr, 10, 64)
+	if err != nil || start < 0 || start >= size {
+		return 0, 0, false, false
+	}
+	end := size - 1
+	if endStr != "" {
+		end, err = strconv.ParseInt(endStr, 10, 64)
+		if err != nil || end < start {
+			return 0, 0, false, false
+		}
+		end = min(end, size-1)
+	}
+	return start, end - start + 1, true, true
+}
@@ -0,0 +1,39 @@
+package handler
+
+import "testing"
+
+func TestParseByteRange(t *testing.T) {
+	tests := []struct {
+		header  string
+		size    int64
+		offset  int64
+		length  int64
+		partial bool
+		ok      bool
+	}{
+		{"", 100, 0, 100, false, true},
+		{"items=0-10", 100, 0, 100, false, true},
+		{"bytes=0-9,20-29", 100, 0, 100, false, true},
+		{"bytes=0-9", 100, 0, 10, true, true},
+		{"bytes=90-", 100, 90, 10, true, true},
+		{"bytes=90-200", 100, 90, 10, true, true},
+		{"bytes=99-99", 100, 99, 1, true, true},
+		{"bytes=-10", 100, 90, 10, true, true},
+		{"bytes=-200", 100, 0, 100, true, true},
+		{"bytes=100-", 100, 0, 0, false, false},
+		{"bytes=10-5", 100, 0, 0, false, false},
+		{"bytes=-0", 100, 0, 0, false, false},
+		{"bytes=-5", 0, 0, 0, false, false},
+		{"bytes=0-", 0, 0, 0, false, false},
+		{"bytes=", 100, 0, 0, false, false},
+		{"bytes=a-b", 100, 0, 0, false, false},
+		{"", 0, 0, 0, false, true},
+	}
+	for _, tt := range tests {
+		offset, length, partial, ok := parseByteRange(tt.header, tt.size)
+		if offset != tt.offset || length != tt.length || partial != tt.partial || ok != tt.ok {
+			t.Errorf("parseByteRange(%q, %d) = (%d, %d, %v, %v), want (%d, %d, %v, %v)",
+				tt.header, tt.size, offset, length, partial, ok, tt.offset, tt.length, tt.partial, tt.ok)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/Cohen-J-Omer/k8-task-mgmt-system/taskmgmt/internal/validator"
+	pb "github.com/Cohen-J-Omer/k8-task-mgmt-system/taskmgmt/proto"
+	"github.com/gin-gonic/gin"
+)
+
+// AddChecklistItem appends an item to a task's checklist.
+func (h *TaskHandler) AddChecklistItem(c *gin.Context) {
+	var item pb.ChecklistItem
+	// bind the JSON body to the checklist item struct
+	if err := c.ShouldBindJSON(&item); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err := validator.ValidateChecklistItem(&item); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	ctx := c.Request.Context()
+	task, err := h.client.AddChecklistItem(ctx, &pb.ChecklistItemRequest{TaskId: c.Param("id"), Item: &item})
+	if err != nil {
+		respondRPCError(c, err, "failed to add checklist item")
+		return
+	}
+	c.JSON(http.StatusCreated, task)
+}
+
+// ToggleChecklistItem flips the done state of a checklist item.
+func (h *TaskHandler) ToggleChecklistItem(c *gin.Context) {
+	ctx := c.Request.Context()
+	task, err := h.client.ToggleChecklistItem(ctx, &pb.ChecklistItemID{TaskId: c.Param("id"), ItemId: c.Param("item_id")})
+	if err != nil {
+		respondRPCError(c, err, "failed to toggle checklist item")
+		return
+	}
+	c.JSON(http.StatusOK, task)
+}
+
+// ReorderChecklist reorders a task's checklist, expecting {"item_ids": [...]} listing every item in its new order.
+func (h *TaskHandler) ReorderChecklist(c *gin.Context) {
+	var req pb.ChecklistOrder
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	req.TaskId = c.Param("id")
+	ctx := c.Request.Context()
+	task, err := h.client.ReorderChecklist(ctx, &req)
+	if err != nil {
+		respondRPCError(c, err, "failed to reorder checklist")
+		return
+	}
+	c.JSON(http.StatusOK, task)
+}
+
+// RemoveChecklistItem removes an item from a task's checklist.
+func (h *TaskHandler) RemoveChecklistItem(c *gin.Context) {
+	ctx := c.Request.Context()
+	task, err := h.client.RemoveChecklistItem(ctx, &pb.ChecklistItemID{TaskId: c.Param("id"), ItemId: c.Param("item_id")})
+	if err != nil {
+		respondRPCError(c, err, "failed to remove checklist item")
+		return
+	}
+	c.JSON(http.StatusOK, task)
+}
@@ -0,0 +1,59 @@
+package handler
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+
+	"github.com/Cohen-J-Omer/k8-task-mgmt-system/taskmgmt/internal/resilience"
+	"github.com/Cohen-J-Omer/k8-task-mgmt-system/taskmgmt/internal/validator"
+	"github.com/gin-gonic/gin"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// httpStatusFromRPC maps the gRPC status code of a backend error to an HTTP status code, refined by
+// the reason of its ErrorInfo detail, if any.
+func httpStatusFromRPC(err error) int {
+	for _, d := range status.Convert(err).Details() {
+		if info, ok := d.(*errdetails.ErrorInfo); ok && info.Reason == validator.AttachmentTooLargeReason {
+			return http.StatusRequestEntityTooLarge
+		}
+	}
+	switch status.Code(err) {
+	case codes.InvalidArgument, codes.OutOfRange:
+		return http.StatusBadRequest
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.AlreadyExists, codes.FailedPrecondition, codes.Aborted:
+		return http.StatusConflict
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	case codes.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// respondRPCError writes a backend error as a JSON error response.
+// The backend's message is only exposed for client errors; internal errors are replaced by fallback.
+// Errors telling when to retry, such as those of the open circuit breaker, set Retry-After.
+func respondRPCError(c *gin.Context, err error, fallback string) {
+	code := httpStatusFromRPC(err)
+	if wait, ok := resilience.RetryAfter(err); ok {
+		c.Header("Retry-After", strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+	}
+	msg := fallback
+	if code < http.StatusInternalServerError {
+		msg = status.Convert(err).Message()
+	}
+	c.JSON(code, gin.H{"error": msg})
+}
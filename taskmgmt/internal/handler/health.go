@@ -0,0 +1,74 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// backendDependencies are the backend's health services reported in verbose readiness checks,
+// by dependency name.
+var backendDependencies = map[string]string{
+	"mongodb": "mongodb",
+}
+
+// HealthHandler handles Kubernetes probes, checking the backend's gRPC health service for readiness.
+type HealthHandler struct {
+	client   healthpb.HealthClient
+	timeout  time.Duration
+	draining atomic.Bool // set once the API shuts down
+}
+
+func NewHealthHandler(client healthpb.HealthClient, timeout time.Duration) *HealthHandler {
+	return &HealthHandler{client: client, timeout: timeout}
+}
+
+// Livez reports that the API is running. It checks no dependency, so an outage of one doesn't get
+// the API restarted.
+func (h *HealthHandler) Livez(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"status": "ok"})
+}
+
+// Drain fails readiness from now on, so the API is taken out of load balancing before it shuts down.
+func (h *HealthHandler) Drain() {
+	h.draining.Store(true)
+}
+
+// Readyz reports whether the API can serve requests, that is whether the backend reports itself
+// serving, failing with 503 otherwise or once the API is draining. With the verbose query parameter, the status of each of
+// the backend's dependencies is detailed too.
+func (h *HealthHandler) Readyz(c *gin.Context) {
+	if h.draining.Load() {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"status": "draining"})
+		return
+	}
+	backend := h.check(c.Request.Context(), "")
+	code, overall := http.StatusOK, "ok"
+	if backend != "SERVING" {
+		code, overall = http.StatusServiceUnavailable, "unavailable"
+	}
+	if _, verbose := c.GetQuery("verbose"); !verbose {
+		c.JSON(code, gin.H{"status": overall})
+		return
+	}
+	checks := gin.H{"backend": backend}
+	for name, service := range backendDependencies {
+		checks["backend/"+name] = h.check(c.Request.Context(), service)
+	}
+	c.JSON(code, gin.H{"status": overall, "checks": checks})
+}
+
+// check returns the backend's status of the health service, e.g. SERVING, or why it couldn't be checked.
+func (h *HealthHandler) check(ctx context.Context, service string) string {
+	ctx, cancel := context.WithTimeout(ctx, h.timeout)
+	defer cancel()
+	resp, err := h.client.Check(ctx, &healthpb.HealthCheckRequest{Service: service})
+	if err != nil {
+		return "unreachable: " + err.Error()
+	}
+	return resp.Status.String()
+}
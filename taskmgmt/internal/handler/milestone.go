@@ -0,0 +1,104 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/Cohen-J-Omer/k8-task-mgmt-system/taskmgmt/internal/validator"
+	pb "github.com/Cohen-J-Omer/k8-task-mgmt-system/taskmgmt/proto"
+	"github.com/gin-gonic/gin"
+)
+
+// MilestoneHandler handles API HTTP requests for milestones and their burndown charts
+// using a gRPC client to communicate with the backend service.
+type MilestoneHandler struct {
+	client pb.MilestoneServiceClient
+}
+
+func NewMilestoneHandler(client pb.MilestoneServiceClient) *MilestoneHandler {
+	return &MilestoneHandler{client: client}
+}
+
+// CreateMilestone handles the creation of a new milestone.
+func (h *MilestoneHandler) CreateMilestone(c *gin.Context) {
+	var req pb.Milestone
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err := validator.ValidateMilestone(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	ctx := c.Request.Context()
+	resp, err := h.client.CreateMilestone(ctx, &req)
+	if err != nil {
+		respondRPCError(c, err, "failed to create milestone")
+		return
+	}
+	c.JSON(http.StatusCreated, resp)
+}
+
+// GetMilestones retrieves all milestones.
+func (h *MilestoneHandler) GetMilestones(c *gin.Context) {
+	ctx := c.Request.Context()
+	list, err := h.client.GetMilestones(ctx, &pb.Empty{})
+	if err != nil {
+		respondRPCError(c, err, "failed to list milestones")
+		return
+	}
+	c.JSON(http.StatusOK, list)
+}
+
+// GetMilestone retrieves a milestone by its ID.
+func (h *MilestoneHandler) GetMilestone(c *gin.Context) {
+	ctx := c.Request.Context()
+	milestone, err := h.client.GetMilestone(ctx, &pb.MilestoneID{Id: c.Param("id")})
+	if err != nil {
+		respondRPCError(c, err, "failed to get milestone")
+		return
+	}
+	c.JSON(http.StatusOK, milestone)
+}
+
+// UpdateMilestone replaces a milestone's name and dates.
+func (h *MilestoneHandler) UpdateMilestone(c *gin.Context) {
+	var req pb.Milestone
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	req.Id = c.Param("id")
+	if err := validator.ValidateMilestone(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	ctx := c.Request.Context()
+	milestone, err := h.client.UpdateMilestone(ctx, &req)
+	if err != nil {
+		respondRPCError(c, err, "failed to update milestone")
+		return
+	}
+	c.JSON(http.StatusOK, milestone)
+}
+
+// DeleteMilestone deletes a milestone without tasks.
+func (h *MilestoneHandler) DeleteMilestone(c *gin.Context) {
+	ctx := c.Request.Context()
+	milestone, err := h.client.DeleteMilestone(ctx, &pb.MilestoneID{Id: c.Param("id")})
+	if err != nil {
+		respondRPCError(c, err, "failed to delete milestone")
+		return
+	}
+	c.JSON(http.StatusOK, milestone)
+}
+
+// GetBurndown retrieves the open tasks and points of a milestone at the end of each of its days.
+func (h *MilestoneHandler) GetBurndown(c *gin.Context) {
+	ctx := c.Request.Context()
+	burndown, err := h.client.GetBurndown(ctx, &pb.MilestoneID{Id: c.Param("id")})
+	if err != nil {
+		respondRPCError(c, err, "failed to get burndown")
+		return
+	}
+	c.JSON(http.StatusOK, burndown)
+}
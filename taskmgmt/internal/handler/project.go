@@ -0,0 +1,189 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/Cohen-J-Omer/k8-task-mgmt-system/taskmgmt/internal/validator"
+	pb "github.com/Cohen-J-Omer/k8-task-mgmt-system/taskmgmt/proto"
+	"github.com/gin-gonic/gin"
+)
+
+// ProjectHandler handles API HTTP requests for projects and their boards
+// using a gRPC client to communicate with the backend service.
+type ProjectHandler struct {
+	client pb.ProjectServiceClient
+}
+
+func NewProjectHandler(client pb.ProjectServiceClient) *ProjectHandler {
+	return &ProjectHandler{client: client}
+}
+
+// CreateProject handles the creation of a new project.
+func (h *ProjectHandler) CreateProject(c *gin.Context) {
+	var req pb.Project
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err := validator.ValidateProject(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	ctx := c.Request.Context()
+	resp, err := h.client.CreateProject(ctx, &req)
+	if err != nil {
+		respondRPCError(c, err, "failed to create project")
+		return
+	}
+	c.JSON(http.StatusCreated, resp)
+}
+
+// GetProjects retrieves all projects.
+func (h *ProjectHandler) GetProjects(c *gin.Context) {
+	ctx := c.Request.Context()
+	list, err := h.client.GetProjects(ctx, &pb.Empty{})
+	if err != nil {
+		respondRPCError(c, err, "failed to list projects")
+		return
+	}
+	c.JSON(http.StatusOK, list)
+}
+
+// GetProject retrieves a project by its ID.
+func (h *ProjectHandler) GetProject(c *gin.Context) {
+	ctx := c.Request.Context()
+	project, err := h.client.GetProject(ctx, &pb.ProjectID{Id: c.Param("id")})
+	if err != nil {
+		respondRPCError(c, err, "failed to get project")
+		return
+	}
+	c.JSON(http.StatusOK, project)
+}
+
+// UpdateProject replaces a project's name and description.
+func (h *ProjectHandler) UpdateProject(c *gin.Context) {
+	var req pb.Project
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	req.Id = c.Param("id")
+	if err := validator.ValidateProject(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	ctx := c.Request.Context()
+	project, err := h.client.UpdateProject(ctx, &req)
+	if err != nil {
+		respondRPCError(c, err, "failed to update project")
+		return
+	}
+	c.JSON(http.StatusOK, project)
+}
+
+// DeleteProject deletes a project without tasks, along with its boards.
+func (h *ProjectHandler) DeleteProject(c *gin.Context) {
+	ctx := c.Request.Context()
+	project, err := h.client.DeleteProject(ctx, &pb.ProjectID{Id: c.Param("id")})
+	if err != nil {
+		respondRPCError(c, err, "failed to delete project")
+		return
+	}
+	c.JSON(http.StatusOK, project)
+}
+
+// CreateBoard handles the creation of a new board.
+func (h *ProjectHandler) CreateBoard(c *gin.Context) {
+	var req pb.Board
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err := validator.ValidateBoard(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	ctx := c.Request.Context()
+	resp, err := h.client.CreateBoard(ctx, &req)
+	if err != nil {
+		respondRPCError(c, err, "failed to create board")
+		return
+	}
+	c.JSON(http.StatusCreated, resp)
+}
+
+// GetBoards retrieves all boards, optionally only those of the "project" query parameter.
+func (h *ProjectHandler) GetBoards(c *gin.Context) {
+	ctx := c.Request.Context()
+	list, err := h.client.GetBoards(ctx, &pb.BoardFilter{ProjectId: c.Query("project")})
+	if err != nil {
+		respondRPCError(c, err, "failed to list boards")
+		return
+	}
+	c.JSON(http.StatusOK, list)
+}
+
+// GetBoard retrieves a board with its columns' ordered cards.
+func (h *ProjectHandler) GetBoard(c *gin.Context) {
+	ctx := c.Request.Context()
+	view, err := h.client.GetBoard(ctx, &pb.BoardID{Id: c.Param("id")})
+	if err != nil {
+		respondRPCError(c, err, "failed to get board")
+		return
+	}
+	c.JSON(http.StatusOK, view)
+}
+
+// UpdateBoard replaces a board's project, name and columns.
+func (h *ProjectHandler) UpdateBoard(c *gin.Context) {
+	var req pb.Board
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	req.Id = c.Param("id")
+	if err := validator.ValidateBoard(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	ctx := c.Request.Context()
+	board, err := h.client.UpdateBoard(ctx, &req)
+	if err != nil {
+		respondRPCError(c, err, "failed to update board")
+		return
+	}
+	c.JSON(http.StatusOK, board)
+}
+
+// DeleteBoard deletes a board.
+func (h *ProjectHandler) DeleteBoard(c *gin.Context) {
+	ctx := c.Request.Context()
+	board, err := h.client.DeleteBoard(ctx, &pb.BoardID{Id: c.Param("id")})
+	if err != nil {
+		respondRPCError(c, err, "failed to delete board")
+		return
+	}
+	c.JSON(http.StatusOK, board)
+}
+
+// MoveCard moves a task's card on a board, expecting {"column": "...", "before_task_id": "...", "force": false}.
+// Moves into a column at its WIP limit fail with 409 unless forced, in which case the response carries a warning.
+func (h *ProjectHandler) MoveCard(c *gin.Context) {
+	var req pb.MoveCardRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if req.Column == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "column is required"})
+		return
+	}
+	req.BoardId, req.TaskId = c.Param("id"), c.Param("task_id")
+	ctx := c.Request.Context()
+	resp, err := h.client.MoveCard(ctx, &req)
+	if err != nil {
+		respondRPCError(c, err, "failed to move card")
+		return
+	}
+	c.JSON(http.StatusOK, resp)
+}
@@ -0,0 +1,51 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	pb "github.com/Cohen-J-Omer/k8-task-mgmt-system/taskmgmt/proto"
+	"github.com/gin-gonic/gin"
+)
+
+// defaultOccurrenceWindow is previewed when the "to" query parameter is omitted.
+const defaultOccurrenceWindow = 90 * 24 * time.Hour
+
+// ListOccurrences previews the upcoming occurrences of a recurring task.
+// The optional "from" and "to" query parameters are RFC 3339 timestamps, defaulting to now and 90 days after from.
+func (h *TaskHandler) ListOccurrences(c *gin.Context) {
+	from := time.Now().UTC()
+	if v := c.Query("from"); v != "" {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "from must be an RFC 3339 timestamp"})
+			return
+		}
+		from = t
+	}
+	to := from.Add(defaultOccurrenceWindow)
+	if v := c.Query("to"); v != "" {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "to must be an RFC 3339 timestamp"})
+			return
+		}
+		to = t
+	}
+	if to.Before(from) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "to must not be before from"})
+		return
+	}
+
+	ctx := c.Request.Context()
+	list, err := h.client.ListOccurrences(ctx, &pb.OccurrenceRange{
+		TaskId: c.Param("id"),
+		From:   from.Format(time.RFC3339),
+		To:     to.Format(time.RFC3339),
+	})
+	if err != nil {
+		respondRPCError(c, err, "failed to list occurrences")
+		return
+	}
+	c.JSON(http.StatusOK, list)
+}
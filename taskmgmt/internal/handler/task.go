@@ -1,10 +1,8 @@
 package handler
 
 import (
-	"context"
 	"fmt"
 	"net/http"
-	"time"
 
 	"github.com/Cohen-J-Omer/k8-task-mgmt-system/taskmgmt/internal/validator"
 	pb "github.com/Cohen-J-Omer/k8-task-mgmt-system/taskmgmt/proto"
@@ -12,15 +10,17 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
-// implements grpc's TaskServiceClient 
+
+// implements grpc's TaskServiceClient
 // TaskHandler handles API HTTP requests for task management
 // using a gRPC client to communicate with the backend service.
 type TaskHandler struct {
-	client pb.TaskServiceClient
+	client           pb.TaskServiceClient
+	attachmentLimits validator.AttachmentLimits
 }
 
-func NewTaskHandler(client pb.TaskServiceClient) *TaskHandler {
-	return &TaskHandler{client: client}
+func NewTaskHandler(client pb.TaskServiceClient, attachmentLimits validator.AttachmentLimits) *TaskHandler {
+	return &TaskHandler{client: client, attachmentLimits: attachmentLimits}
 }
 
 // CreateTask handles the creation of a new task.
@@ -36,61 +36,58 @@ func (h *TaskHandler) CreateTask(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
-	// Set a timeout context for the gRPC call
-	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
-	defer cancel()
+	ctx := c.Request.Context()
 	// Create the task using the gRPC client
 	resp, err := h.client.CreateTask(ctx, &req)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondRPCError(c, err, "failed to create task")
 		return
 	}
 	c.JSON(http.StatusCreated, resp)
 }
 
 // GetTasks retrieves a list of tasks from the backend service.
+// The optional "assignee" and "watcher" query parameters filter by user ID, "me" referring to the caller,
+// "project" by project ID and "milestone" by milestone ID.
 func (h *TaskHandler) GetTasks(c *gin.Context) {
+	filter := &pb.TaskFilter{AssigneeId: c.Query("assignee"), WatcherId: c.Query("watcher"), ProjectId: c.Query("project"), MilestoneId: c.Query("milestone")}
 	// Call the gRPC service to get the list of tasks
-	// not using a timeout here, as it may take longer to fetch tasks
-	taskList, err := h.client.GetTasks(c.Request.Context(), &pb.Empty{})
-    if err != nil {
-        c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to list tasks"})
-        return
-    }
+	// the route's deadline is longer than most, as it may take longer to fetch tasks
+	taskList, err := h.client.GetTasks(c.Request.Context(), filter)
+	if err != nil {
+		respondRPCError(c, err, "failed to list tasks")
+		return
+	}
 	// Ensure tasks is an array, not nil
-    if taskList.Tasks == nil {
-        taskList.Tasks = []*pb.Task{}
-    }
-    c.JSON(http.StatusOK, taskList)
+	if taskList.Tasks == nil {
+		taskList.Tasks = []*pb.Task{}
+	}
+	c.JSON(http.StatusOK, taskList)
 }
 
 // GetTask retrieves a specific task by its ID.
 func (h *TaskHandler) GetTask(c *gin.Context) {
-    id := c.Param("id") // Extract the task ID from the URL parameter
+	id := c.Param("id") // Extract the task ID from the URL parameter
 	// Validate that the ID is not empty
 	if id == "" {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "task ID is required"})
 		return
 	}
-    req := &pb.TaskID{Id: id}
+	req := &pb.TaskID{Id: id}
 
-	// Set a timeout context for the gRPC call
-    ctx, cancel := context.WithTimeout(c.Request.Context(), 2*time.Second)
-    defer cancel()
+	ctx := c.Request.Context()
 
-    task, err := h.client.GetTask(ctx, req)
-    if err != nil {
-        status, ok := status.FromError(err)
+	task, err := h.client.GetTask(ctx, req)
+	if err != nil {
 		// Check if the error is a NotFound error
-        if ok && status.Code() == codes.NotFound {
-            c.JSON(http.StatusNotFound, gin.H{"error": fmt.Sprintf("task with id %s not found", id)})
-            return
-        }
-		// For other errors, return a generic internal server error
-        c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get task"})
-        return
-    }
-    c.JSON(http.StatusOK, task)
+		if status.Code(err) == codes.NotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": fmt.Sprintf("task with id %s not found", id)})
+			return
+		}
+		respondRPCError(c, err, "failed to get task")
+		return
+	}
+	c.JSON(http.StatusOK, task)
 }
 
 // UpdateTask updates an existing task by its ID.
@@ -113,13 +110,11 @@ func (h *TaskHandler) UpdateTask(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
-	// Set a timeout context for the gRPC call
-	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
-	defer cancel()
+	ctx := c.Request.Context()
 	// Update the task using the gRPC client
 	resp, err := h.client.UpdateTask(ctx, &req)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondRPCError(c, err, "failed to update task")
 		return
 	}
 	c.JSON(http.StatusOK, resp)
@@ -127,29 +122,20 @@ func (h *TaskHandler) UpdateTask(c *gin.Context) {
 
 // DeleteTask deletes a specific task by its ID.
 func (h *TaskHandler) DeleteTask(c *gin.Context) {
-    id := c.Param("id")
+	id := c.Param("id")
 	// Validate that the ID is not empty
 	if id == "" {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "task ID is required"})
 		return
 	}
-    req := &pb.TaskID{Id: id}
+	req := &pb.TaskID{Id: id}
 
-	// Set a timeout context for the gRPC call
-	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
-	defer cancel()
+	ctx := c.Request.Context()
 
-    deletedTask, err := h.client.DeleteTask(ctx, req)
-    if err != nil {
-        status, ok := status.FromError(err)
-		// Check if the error is a NotFound error
-        if ok && status.Code() == codes.NotFound {
-            c.JSON(http.StatusNotFound, gin.H{"error": fmt.Sprintf("task with id %s not found", id)})
-            return
-        }
-		// For other errors, return a generic internal server error
-        c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to delete task"})
-        return
-    }
-    c.JSON(http.StatusOK, deletedTask)
+	deletedTask, err := h.client.DeleteTask(ctx, req)
+	if err != nil {
+		respondRPCError(c, err, "failed to delete task")
+		return
+	}
+	c.JSON(http.StatusOK, deletedTask)
 }
@@ -0,0 +1,113 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/Cohen-J-Omer/k8-task-mgmt-system/taskmgmt/internal/validator"
+	pb "github.com/Cohen-J-Omer/k8-task-mgmt-system/taskmgmt/proto"
+	"github.com/gin-gonic/gin"
+)
+
+// TemplateHandler handles API HTTP requests for task templates
+// using a gRPC client to communicate with the backend service.
+type TemplateHandler struct {
+	client pb.TemplateServiceClient
+}
+
+func NewTemplateHandler(client pb.TemplateServiceClient) *TemplateHandler {
+	return &TemplateHandler{client: client}
+}
+
+// CreateTemplate handles the creation of a new template.
+func (h *TemplateHandler) CreateTemplate(c *gin.Context) {
+	var req pb.TaskTemplate
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err := validator.ValidateTemplate(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	ctx := c.Request.Context()
+	resp, err := h.client.CreateTemplate(ctx, &req)
+	if err != nil {
+		respondRPCError(c, err, "failed to create template")
+		return
+	}
+	c.JSON(http.StatusCreated, resp)
+}
+
+// GetTemplates retrieves all templates.
+func (h *TemplateHandler) GetTemplates(c *gin.Context) {
+	list, err := h.client.GetTemplates(c.Request.Context(), &pb.Empty{})
+	if err != nil {
+		respondRPCError(c, err, "failed to list templates")
+		return
+	}
+	c.JSON(http.StatusOK, list)
+}
+
+// GetTemplate retrieves a template by its name.
+func (h *TemplateHandler) GetTemplate(c *gin.Context) {
+	ctx := c.Request.Context()
+	tmpl, err := h.client.GetTemplate(ctx, &pb.TemplateName{Name: c.Param("name")})
+	if err != nil {
+		respondRPCError(c, err, "failed to get template")
+		return
+	}
+	c.JSON(http.StatusOK, tmpl)
+}
+
+// UpdateTemplate replaces a template by its name, creating it if it does not exist.
+func (h *TemplateHandler) UpdateTemplate(c *gin.Context) {
+	var req pb.TaskTemplate
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	req.Name = c.Param("name")
+	if err := validator.ValidateTemplate(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	ctx := c.Request.Context()
+	resp, err := h.client.UpdateTemplate(ctx, &req)
+	if err != nil {
+		respondRPCError(c, err, "failed to update template")
+		return
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// DeleteTemplate deletes a template by its name.
+func (h *TemplateHandler) DeleteTemplate(c *gin.Context) {
+	ctx := c.Request.Context()
+	tmpl, err := h.client.DeleteTemplate(ctx, &pb.TemplateName{Name: c.Param("name")})
+	if err != nil {
+		respondRPCError(c, err, "failed to delete template")
+		return
+	}
+	c.JSON(http.StatusOK, tmpl)
+}
+
+// InstantiateTemplate creates all tasks of a template, expecting an optional
+// {"variables": {...}, "base_date": "...", "milestone_id": "..."} body.
+func (h *TemplateHandler) InstantiateTemplate(c *gin.Context) {
+	var req pb.InstantiateRequest
+	// an empty body instantiates a template without variables relative to now
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+	req.Name = c.Param("name")
+	ctx := c.Request.Context()
+	list, err := h.client.InstantiateTemplate(ctx, &req)
+	if err != nil {
+		respondRPCError(c, err, "failed to instantiate template")
+		return
+	}
+	c.JSON(http.StatusCreated, list)
+}
@@ -0,0 +1,209 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/Cohen-J-Omer/k8-task-mgmt-system/taskmgmt/internal/validator"
+	pb "github.com/Cohen-J-Omer/k8-task-mgmt-system/taskmgmt/proto"
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc"
+)
+
+// UserHandler handles API HTTP requests for users
+// using a gRPC client to communicate with the backend service.
+type UserHandler struct {
+	client pb.UserServiceClient
+}
+
+func NewUserHandler(client pb.UserServiceClient) *UserHandler {
+	return &UserHandler{client: client}
+}
+
+// CreateUser handles the creation of a new user.
+func (h *UserHandler) CreateUser(c *gin.Context) {
+	var req pb.User
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err := validator.ValidateUser(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	ctx := c.Request.Context()
+	resp, err := h.client.CreateUser(ctx, &req)
+	if err != nil {
+		respondRPCError(c, err, "failed to create user")
+		return
+	}
+	c.JSON(http.StatusCreated, resp)
+}
+
+// GetUsers retrieves all users.
+func (h *UserHandler) GetUsers(c *gin.Context) {
+	list, err := h.client.GetUsers(c.Request.Context(), &pb.Empty{})
+	if err != nil {
+		respondRPCError(c, err, "failed to list users")
+		return
+	}
+	c.JSON(http.StatusOK, list)
+}
+
+// GetUser retrieves a user by its ID.
+func (h *UserHandler) GetUser(c *gin.Context) {
+	ctx := c.Request.Context()
+	user, err := h.client.GetUser(ctx, &pb.UserID{Id: c.Param("id")})
+	if err != nil {
+		respondRPCError(c, err, "failed to get user")
+		return
+	}
+	c.JSON(http.StatusOK, user)
+}
+
+// UpdateUser replaces a user's name, email and role.
+func (h *UserHandler) UpdateUser(c *gin.Context) {
+	var req pb.User
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	req.Id = c.Param("id")
+	if err := validator.ValidateUser(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	ctx := c.Request.Context()
+	user, err := h.client.UpdateUser(ctx, &req)
+	if err != nil {
+		respondRPCError(c, err, "failed to update user")
+		return
+	}
+	c.JSON(http.StatusOK, user)
+}
+
+// CreateTeam handles the creation of a new team, expecting {"id": "...", "name": "...", "member_ids": [...]}.
+func (h *UserHandler) CreateTeam(c *gin.Context) {
+	var req pb.Team
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err := validator.ValidateTeam(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	ctx := c.Request.Context()
+	resp, err := h.client.CreateTeam(ctx, &req)
+	if err != nil {
+		respondRPCError(c, err, "failed to create team")
+		return
+	}
+	c.JSON(http.StatusCreated, resp)
+}
+
+// GetTeams retrieves all teams.
+func (h *UserHandler) GetTeams(c *gin.Context) {
+	list, err := h.client.GetTeams(c.Request.Context(), &pb.Empty{})
+	if err != nil {
+		respondRPCError(c, err, "failed to list teams")
+		return
+	}
+	c.JSON(http.StatusOK, list)
+}
+
+// GetTeam retrieves a team by its ID.
+func (h *UserHandler) GetTeam(c *gin.Context) {
+	ctx := c.Request.Context()
+	team, err := h.client.GetTeam(ctx, &pb.TeamID{Id: c.Param("id")})
+	if err != nil {
+		respondRPCError(c, err, "failed to get team")
+		return
+	}
+	c.JSON(http.StatusOK, team)
+}
+
+// AddTeamMember adds a user to a team, expecting {"user_id": "..."}.
+func (h *UserHandler) AddTeamMember(c *gin.Context) {
+	var req pb.TeamMember
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if req.UserId == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "user_id is required"})
+		return
+	}
+	req.TeamId = c.Param("id")
+	ctx := c.Request.Context()
+	team, err := h.client.AddTeamMember(ctx, &req)
+	if err != nil {
+		respondRPCError(c, err, "failed to add team member")
+		return
+	}
+	c.JSON(http.StatusOK, team)
+}
+
+// RemoveTeamMember removes a user from a team.
+func (h *UserHandler) RemoveTeamMember(c *gin.Context) {
+	ctx := c.Request.Context()
+	team, err := h.client.RemoveTeamMember(ctx, &pb.TeamMember{TeamId: c.Param("id"), UserId: c.Param("user_id")})
+	if err != nil {
+		respondRPCError(c, err, "failed to remove team member")
+		return
+	}
+	c.JSON(http.StatusOK, team)
+}
+
+// AddAssignee assigns a user to a task, expecting {"user_id": "..."} where "me" refers to the caller.
+func (h *TaskHandler) AddAssignee(c *gin.Context) {
+	h.addTaskUser(c, h.client.AddAssignee, "failed to add assignee")
+}
+
+// RemoveAssignee unassigns a user from a task.
+func (h *TaskHandler) RemoveAssignee(c *gin.Context) {
+	h.removeTaskUser(c, h.client.RemoveAssignee, "failed to remove assignee")
+}
+
+// AddWatcher adds a user to a task's watchers, expecting {"user_id": "..."} where "me" refers to the caller.
+func (h *TaskHandler) AddWatcher(c *gin.Context) {
+	h.addTaskUser(c, h.client.AddWatcher, "failed to add watcher")
+}
+
+// RemoveWatcher removes a user from a task's watchers.
+func (h *TaskHandler) RemoveWatcher(c *gin.Context) {
+	h.removeTaskUser(c, h.client.RemoveWatcher, "failed to remove watcher")
+}
+
+// taskUserCall is an RPC adding or removing a user to/from one of a task's user lists.
+type taskUserCall func(context.Context, *pb.TaskUser, ...grpc.CallOption) (*pb.Task, error)
+
+// addTaskUser binds the user ID from the body and forwards it to the given RPC.
+func (h *TaskHandler) addTaskUser(c *gin.Context, call taskUserCall, fallback string) {
+	var req pb.TaskUser
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if req.UserId == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "user_id is required"})
+		return
+	}
+	req.TaskId = c.Param("id")
+	h.callTaskUser(c, call, &req, fallback)
+}
+
+// removeTaskUser forwards the user ID from the URL to the given RPC.
+func (h *TaskHandler) removeTaskUser(c *gin.Context, call taskUserCall, fallback string) {
+	h.callTaskUser(c, call, &pb.TaskUser{TaskId: c.Param("id"), UserId: c.Param("user_id")}, fallback)
+}
+
+func (h *TaskHandler) callTaskUser(c *gin.Context, call taskUserCall, req *pb.TaskUser, fallback string) {
+	ctx := c.Request.Context()
+	task, err := call(ctx, req)
+	if err != nil {
+		respondRPCError(c, err, fallback)
+		return
+	}
+	c.JSON(http.StatusOK, task)
+}
@@ -0,0 +1,122 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/Cohen-J-Omer/k8-task-mgmt-system/taskmgmt/internal/validator"
+	pb "github.com/Cohen-J-Omer/k8-task-mgmt-system/taskmgmt/proto"
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc"
+)
+
+// defaultReportWindow is reported when the "from" query parameter is omitted.
+const defaultReportWindow = 30 * 24 * time.Hour
+
+// StartTimer starts a timer on a task, expecting an optional {"user": "..."} defaulting to the caller.
+func (h *TaskHandler) StartTimer(c *gin.Context) {
+	h.timer(c, h.client.StartTimer, "failed to start timer")
+}
+
+// StopTimer stops a running timer on a task, expecting an optional {"user": "...", "note": "..."},
+// and logs the elapsed time as a worklog.
+func (h *TaskHandler) StopTimer(c *gin.Context) {
+	h.timer(c, h.client.StopTimer, "failed to stop timer")
+}
+
+// timer binds a timer request and forwards it to the given timer RPC.
+func (h *TaskHandler) timer(c *gin.Context, call func(context.Context, *pb.TimerRequest, ...grpc.CallOption) (*pb.Task, error), fallback string) {
+	var req pb.TimerRequest
+	// an empty body starts or stops the caller's timer
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+	req.TaskId = c.Param("id")
+	ctx := c.Request.Context()
+	task, err := call(ctx, &req)
+	if err != nil {
+		respondRPCError(c, err, fallback)
+		return
+	}
+	c.JSON(http.StatusOK, task)
+}
+
+// AddWorklog logs a manual worklog entry on a task.
+func (h *TaskHandler) AddWorklog(c *gin.Context) {
+	var worklog pb.Worklog
+	if err := c.ShouldBindJSON(&worklog); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err := validator.ValidateWorklog(&worklog); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	ctx := c.Request.Context()
+	task, err := h.client.AddWorklog(ctx, &pb.WorklogRequest{TaskId: c.Param("id"), Worklog: &worklog})
+	if err != nil {
+		respondRPCError(c, err, "failed to add worklog")
+		return
+	}
+	c.JSON(http.StatusCreated, task)
+}
+
+// DeleteWorklog removes a worklog entry from a task.
+func (h *TaskHandler) DeleteWorklog(c *gin.Context) {
+	ctx := c.Request.Context()
+	task, err := h.client.DeleteWorklog(ctx, &pb.WorklogID{TaskId: c.Param("id"), WorklogId: c.Param("worklog_id")})
+	if err != nil {
+		respondRPCError(c, err, "failed to delete worklog")
+		return
+	}
+	c.JSON(http.StatusOK, task)
+}
+
+// GetTimeReport aggregates logged time by user, label or task.
+// The "from" and "to" query parameters are RFC 3339 timestamps, defaulting to the last 30 days,
+// and "group_by" is one of user (default), label or task.
+func (h *TaskHandler) GetTimeReport(c *gin.Context) {
+	to := time.Now().UTC()
+	if v := c.Query("to"); v != "" {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "to must be an RFC 3339 timestamp"})
+			return
+		}
+		to = t
+	}
+	from := to.Add(-defaultReportWindow)
+	if v := c.Query("from"); v != "" {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "from must be an RFC 3339 timestamp"})
+			return
+		}
+		from = t
+	}
+	if to.Before(from) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "to must not be before from"})
+		return
+	}
+	groupBy := c.DefaultQuery("group_by", "user")
+	if groupBy != "user" && groupBy != "label" && groupBy != "task" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "group_by must be user, label or task"})
+		return
+	}
+
+	// the route's deadline is longer than most, as aggregating all worklogs may take longer
+	report, err := h.client.GetTimeReport(c.Request.Context(), &pb.TimeReportRequest{
+		From:    from.Format(time.RFC3339),
+		To:      to.Format(time.RFC3339),
+		GroupBy: groupBy,
+	})
+	if err != nil {
+		respondRPCError(c, err, "failed to get time report")
+		return
+	}
+	c.JSON(http.StatusOK, report)
+}
@@ -0,0 +1,93 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/Cohen-J-Omer/k8-task-mgmt-system/taskmgmt/internal/validator"
+	pb "github.com/Cohen-J-Omer/k8-task-mgmt-system/taskmgmt/proto"
+	"github.com/gin-gonic/gin"
+)
+
+// WorkspaceHandler handles API HTTP requests for workspaces
+// using a gRPC client to communicate with the backend service.
+type WorkspaceHandler struct {
+	client pb.WorkspaceServiceClient
+}
+
+func NewWorkspaceHandler(client pb.WorkspaceServiceClient) *WorkspaceHandler {
+	return &WorkspaceHandler{client: client}
+}
+
+// CreateWorkspace handles the creation of a new workspace.
+func (h *WorkspaceHandler) CreateWorkspace(c *gin.Context) {
+	var req pb.Workspace
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err := validator.ValidateWorkspace(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	ctx := c.Request.Context()
+	resp, err := h.client.CreateWorkspace(ctx, &req)
+	if err != nil {
+		respondRPCError(c, err, "failed to create workspace")
+		return
+	}
+	c.JSON(http.StatusCreated, resp)
+}
+
+// GetWorkspaces retrieves all workspaces.
+func (h *WorkspaceHandler) GetWorkspaces(c *gin.Context) {
+	ctx := c.Request.Context()
+	list, err := h.client.GetWorkspaces(ctx, &pb.Empty{})
+	if err != nil {
+		respondRPCError(c, err, "failed to list workspaces")
+		return
+	}
+	c.JSON(http.StatusOK, list)
+}
+
+// GetWorkspace retrieves a workspace by its ID.
+func (h *WorkspaceHandler) GetWorkspace(c *gin.Context) {
+	ctx := c.Request.Context()
+	ws, err := h.client.GetWorkspace(ctx, &pb.WorkspaceID{Id: c.Param("id")})
+	if err != nil {
+		respondRPCError(c, err, "failed to get workspace")
+		return
+	}
+	c.JSON(http.StatusOK, ws)
+}
+
+// UpdateWorkspace renames a workspace.
+func (h *WorkspaceHandler) UpdateWorkspace(c *gin.Context) {
+	var req pb.Workspace
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	req.Id = c.Param("id")
+	if err := validator.ValidateWorkspace(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	ctx := c.Request.Context()
+	ws, err := h.client.UpdateWorkspace(ctx, &req)
+	if err != nil {
+		respondRPCError(c, err, "failed to update workspace")
+		return
+	}
+	c.JSON(http.StatusOK, ws)
+}
+
+// DeleteWorkspace deletes an empty workspace.
+func (h *WorkspaceHandler) DeleteWorkspace(c *gin.Context) {
+	ctx := c.Request.Context()
+	ws, err := h.client.DeleteWorkspace(ctx, &pb.WorkspaceID{Id: c.Param("id")})
+	if err != nil {
+		respondRPCError(c, err, "failed to delete workspace")
+		return
+	}
+	c.JSON(http.StatusOK, ws)
+}
@@ -0,0 +1,114 @@
+package identity
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// UserIDMetadataKey is the gRPC metadata key carrying the caller's user ID from the API to the backend.
+const UserIDMetadataKey = "x-user-id"
+
+// TeamIDMetadataKey is the gRPC metadata key carrying the IDs of the caller's teams, one value per team.
+const TeamIDMetadataKey = "x-team-id"
+
+// RoleMetadataKey is the gRPC metadata key carrying the caller's roles, one value per role.
+const RoleMetadataKey = "x-role"
+
+// WorkspaceMetadataKey is the gRPC metadata key carrying the ID of the workspace the caller acts in.
+const WorkspaceMetadataKey = "x-workspace-id"
+
+// DefaultWorkspace is the workspace of callers whose credentials don't name one, and of all data
+// stored before workspaces were introduced.
+const DefaultWorkspace = "default"
+
+// Principal is the authenticated caller of a request.
+type Principal struct {
+	UserID      string
+	TeamIDs     []string
+	Roles       []string
+	WorkspaceID string
+	// Bootstrap is set for the principal of the bootstrap admin key, which only the API knows of.
+	Bootstrap bool
+	// APIKeyID is the ID of the API key the caller authenticated with, if any. Only the API knows of it.
+	APIKeyID string
+}
+
+// HasRole reports whether the principal was granted the role.
+func (p *Principal) HasRole(role string) bool {
+	for _, r := range p.Roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+type contextKey struct{}
+
+// NewContext returns a copy of ctx carrying the principal.
+func NewContext(ctx context.Context, p *Principal) context.Context {
+	return context.WithValue(ctx, contextKey{}, p)
+}
+
+// FromContext returns the principal stored in ctx by NewContext.
+func FromContext(ctx context.Context) (*Principal, bool) {
+	p, ok := ctx.Value(contextKey{}).(*Principal)
+	return p, ok && p != nil
+}
+
+// NewOutgoingContext returns a copy of ctx whose outgoing gRPC metadata identifies the principal.
+func NewOutgoingContext(ctx context.Context, p *Principal) context.Context {
+	if p == nil || p.UserID == "" {
+		return ctx
+	}
+	kv := []string{UserIDMetadataKey, p.UserID}
+	if p.WorkspaceID != "" {
+		kv = append(kv, WorkspaceMetadataKey, p.WorkspaceID)
+	}
+	for _, id := range p.TeamIDs {
+		kv = append(kv, TeamIDMetadataKey, id)
+	}
+	for _, role := range p.Roles {
+		kv = append(kv, RoleMetadataKey, role)
+	}
+	return metadata.AppendToOutgoingContext(ctx, kv...)
+}
+
+// FromIncomingContext returns the principal identified by the incoming gRPC metadata of ctx.
+func FromIncomingContext(ctx context.Context) (*Principal, bool) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return nil, false
+	}
+	ids := md.Get(UserIDMetadataKey)
+	if len(ids) == 0 || ids[0] == "" {
+		return nil, false
+	}
+	p := &Principal{UserID: ids[0], TeamIDs: md.Get(TeamIDMetadataKey), Roles: md.Get(RoleMetadataKey)}
+	if ws := md.Get(WorkspaceMetadataKey); len(ws) > 0 {
+		p.WorkspaceID = ws[0]
+	}
+	return p, true
+}
+
+// UnaryClientInterceptor forwards the principal stored in the call's context as gRPC metadata.
+func UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if p, ok := FromContext(ctx); ok {
+			ctx = NewOutgoingContext(ctx, p)
+		}
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
+
+// StreamClientInterceptor forwards the principal stored in the stream's context as gRPC metadata.
+func StreamClientInterceptor() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		if p, ok := FromContext(ctx); ok {
+			ctx = NewOutgoingContext(ctx, p)
+		}
+		return streamer(ctx, desc, cc, method, opts...)
+	}
+}
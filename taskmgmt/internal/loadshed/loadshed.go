@@ -0,0 +1,124 @@
+// Package loadshed limits the RPCs the backend runs at once, rejecting the excess with ResourceExhausted
+// right away rather than queueing it until callers time out. The limit adapts to the backend's latency
+// (AIMD): it grows slowly while calls are fast and the limit is in use, and shrinks by a fraction when
+// they get slower than the target. Low priority calls, such as full list scans, may only use part of
+// the limit, so they are shed before writes and single reads.
+package loadshed
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/Cohen-J-Omer/k8-task-mgmt-system/taskmgmt/internal/metrics"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// Config configures a Limiter.
+type Config struct {
+	Initial, Min, Max int           // the limit's starting value and bounds; equal values fix it
+	LatencyTarget     time.Duration // calls slower than it shrink the limit
+	LowPriorityShare  float64       // the share of the limit low priority calls may use, e.g. 0.5
+	LowPriority       map[string]bool
+	// Exempt calls are never limited, e.g. health checks, which must answer whatever the load.
+	Exempt map[string]bool
+}
+
+// decrease is the factor the limit shrinks by on slow calls.
+const decrease = 0.9
+
+// retryAfter is how long clients are told to wait before retrying a rejected call.
+const retryAfter = time.Second
+
+// Limiter is an adaptive concurrency limiter.
+type Limiter struct {
+	cfg Config
+
+	mu           sync.Mutex
+	limit        float64
+	inFlight     int
+	lastDecrease time.Time
+}
+
+func NewLimiter(cfg Config) *Limiter {
+	cfg.Min = max(cfg.Min, 1)
+	cfg.Max = max(cfg.Max, cfg.Min)
+	l := &Limiter{cfg: cfg, limit: float64(min(max(cfg.Initial, cfg.Min), cfg.Max))}
+	metrics.ConcurrencyLimit.Set(l.limit)
+	return l
+}
+
+// acquire admits a call unless the calls in flight reach the limit, or its share of it for low
+// priority calls.
+func (l *Limiter) acquire(low bool) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	limit := l.limit
+	if low {
+		limit = max(limit*l.cfg.LowPriorityShare, 1)
+	}
+	if float64(l.inFlight) >= limit {
+		return false
+	}
+	l.inFlight++
+	metrics.ConcurrencyInFlight.Set(float64(l.inFlight))
+	return true
+}
+
+// release ends an admitted call that took latency and returned err, adapting the limit. The limit
+// shrinks at most once per LatencyTarget, so a burst of slow calls finishing together shrinks it once,
+// and only grows while at least half of it is in use, so it doesn't grow unbounded while idle.
+func (l *Limiter) release(latency time.Duration, err error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	busy := float64(l.inFlight) >= l.limit/2
+	l.inFlight--
+	metrics.ConcurrencyInFlight.Set(float64(l.inFlight))
+	switch {
+	case latency > l.cfg.LatencyTarget || status.Code(err) == codes.DeadlineExceeded:
+		if time.Since(l.lastDecrease) >= l.cfg.LatencyTarget {
+			l.limit = max(l.limit*decrease, float64(l.cfg.Min))
+			l.lastDecrease = time.Now()
+		}
+	case busy:
+		l.limit = min(l.limit+1/l.limit, float64(l.cfg.Max))
+	}
+	metrics.ConcurrencyLimit.Set(l.limit)
+}
+
+// rejected is the error of a shed call, telling the client when to retry.
+func rejected() error {
+	st := status.New(codes.ResourceExhausted, "backend overloaded, try again later")
+	if detailed, err := st.WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(retryAfter)}); err == nil {
+		st = detailed
+	}
+	return st.Err()
+}
+
+// UnaryServerInterceptor sheds the unary calls over the limit. Streaming calls, such as attachment
+// transfers, last as long as their size requires and aren't limited.
+func (l *Limiter) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+		if l.cfg.Exempt[info.FullMethod] {
+			return handler(ctx, req)
+		}
+		low := l.cfg.LowPriority[info.FullMethod]
+		if !l.acquire(low) {
+			service, method := metrics.SplitMethod(info.FullMethod)
+			priority := "high"
+			if low {
+				priority = "low"
+			}
+			metrics.LoadShed.WithLabelValues(service, method, priority).Inc()
+			return nil, rejected()
+		}
+		// released even if the handler panics, so the call doesn't hold its slot forever
+		start := time.Now()
+		defer func() { l.release(time.Since(start), err) }()
+		return handler(ctx, req)
+	}
+}
@@ -0,0 +1,166 @@
+package loadshed
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const target = 100 * time.Millisecond
+
+// admit acquires n slots, failing the test if any is refused.
+func admit(t *testing.T, l *Limiter, n int, low bool) {
+	t.Helper()
+	for i := 0; i < n; i++ {
+		if !l.acquire(low) {
+			t.Fatalf("call %d was shed at limit %.2f", i+1, l.limit)
+		}
+	}
+}
+
+func TestLimitGrowsOnlyWhileBusy(t *testing.T) {
+	l := NewLimiter(Config{Initial: 10, Min: 1, Max: 11, LatencyTarget: target})
+
+	// fast calls while less than half the limit is in use leave it alone
+	admit(t, l, 4, false)
+	for i := 0; i < 4; i++ {
+		l.release(time.Millisecond, nil)
+	}
+	if l.limit != 10 {
+		t.Fatalf("idle limiter grew its limit to %.2f", l.limit)
+	}
+
+	admit(t, l, 5, false)
+	l.release(time.Millisecond, nil)
+	if l.limit != 10.1 {
+		t.Errorf("busy limiter's limit is %.2f after a fast call, want 10.1", l.limit)
+	}
+	// but never beyond Max
+	admit(t, l, 2, false)
+	for i := 0; i < 100; i++ {
+		admit(t, l, 1, false)
+		l.release(time.Millisecond, nil)
+	}
+	if l.limit != 11 {
+		t.Errorf("limit grew to %.2f, want at most 11", l.limit)
+	}
+}
+
+func TestLimitShrinksOncePerLatencyTarget(t *testing.T) {
+	tests := []struct {
+		name    string
+		latency time.Duration
+		err     error
+	}{
+		{"slow calls", 2 * target, nil},
+		{"calls past their deadline", time.Millisecond, status.Error(codes.DeadlineExceeded, "deadline exceeded")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := NewLimiter(Config{Initial: 10, Min: 8, Max: 20, LatencyTarget: target})
+			admit(t, l, 3, false)
+
+			// a burst of slow calls finishing together shrinks the limit once
+			l.release(tt.latency, tt.err)
+			l.release(tt.latency, tt.err)
+			if l.limit != 9 {
+				t.Fatalf("limit is %.2f after a burst of slow calls, want 9", l.limit)
+			}
+			// once LatencyTarget passed, it shrinks again, though not below Min
+			l.lastDecrease = time.Now().Add(-target)
+			l.release(tt.latency, tt.err)
+			if l.limit != 8.1 {
+				t.Fatalf("limit is %.2f after another slow call, want 8.1", l.limit)
+			}
+			l.lastDecrease = time.Now().Add(-target)
+			admit(t, l, 1, false)
+			l.release(tt.latency, tt.err)
+			if l.limit != 8 {
+				t.Errorf("limit shrank to %.2f, want Min 8", l.limit)
+			}
+		})
+	}
+}
+
+func TestLowPriorityShare(t *testing.T) {
+	l := NewLimiter(Config{Initial: 4, Min: 4, Max: 4, LatencyTarget: target, LowPriorityShare: 0.5})
+
+	admit(t, l, 2, true)
+	if l.acquire(true) {
+		t.Fatal("low priority call was admitted beyond its share of the limit")
+	}
+	// high priority calls still use the rest of the limit
+	admit(t, l, 2, false)
+	if l.acquire(false) {
+		t.Fatal("high priority call was admitted beyond the limit")
+	}
+
+	// a low priority call may always use at least one slot
+	l = NewLimiter(Config{Initial: 1, Min: 1, Max: 1, LatencyTarget: target, LowPriorityShare: 0.5})
+	admit(t, l, 1, true)
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	const (
+		getTask = "/task.TaskService/GetTask"
+		check   = "/grpc.health.v1.Health/Check"
+	)
+	l := NewLimiter(Config{Initial: 1, Min: 1, Max: 1, LatencyTarget: target, Exempt: map[string]bool{check: true}})
+	intercept := l.UnaryServerInterceptor()
+	call := func(method string, handler grpc.UnaryHandler) error {
+		_, err := intercept(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: method}, handler)
+		return err
+	}
+
+	// while a call holds the only slot, others are shed but exempt ones still run
+	shed, exempt := make(chan error), make(chan error)
+	release, done := make(chan struct{}), make(chan error)
+	go func() {
+		done <- call(getTask, func(context.Context, interface{}) (interface{}, error) {
+			shed <- call(getTask, func(context.Context, interface{}) (interface{}, error) { return nil, nil })
+			exempt <- call(check, func(context.Context, interface{}) (interface{}, error) { return nil, nil })
+			<-release
+			return nil, nil
+		})
+	}()
+	err := <-shed
+	if status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("call over the limit returned %v, want ResourceExhausted", err)
+	}
+	var retryInfo *errdetails.RetryInfo
+	for _, d := range status.Convert(err).Details() {
+		if info, ok := d.(*errdetails.RetryInfo); ok {
+			retryInfo = info
+		}
+	}
+	if retryInfo == nil || retryInfo.RetryDelay.AsDuration() != retryAfter {
+		t.Errorf("shed call carries retry info %v, want a delay of %v", retryInfo, retryAfter)
+	}
+	if err := <-exempt; err != nil {
+		t.Errorf("exempt call was shed: %v", err)
+	}
+	close(release)
+	if err := <-done; err != nil {
+		t.Fatalf("call holding the slot failed: %v", err)
+	}
+
+	// a panicking handler frees its slot too
+	panicked := false
+	func() {
+		defer func() { panicked = recover() != nil }()
+		_ = call(getTask, func(context.Context, interface{}) (interface{}, error) { panic("boom") })
+	}()
+	if !panicked {
+		t.Fatal("handler wasn't called")
+	}
+	handlerErr := errors.New("failed")
+	if err := call(getTask, func(context.Context, interface{}) (interface{}, error) { return nil, handlerErr }); err != handlerErr {
+		t.Errorf("call after a panicked one returned %v, its slot wasn't released", err)
+	}
+}
@@ -0,0 +1,99 @@
+package logging
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/Cohen-J-Omer/k8-task-mgmt-system/taskmgmt/internal/identity"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// outgoingContext returns a copy of ctx whose outgoing metadata carries its request ID, if any.
+func outgoingContext(ctx context.Context) context.Context {
+	if id := RequestID(ctx); id != "" {
+		return metadata.AppendToOutgoingContext(ctx, RequestIDMetadataKey, id)
+	}
+	return ctx
+}
+
+// incomingContext returns a copy of ctx carrying the request ID of its incoming metadata, if any.
+func incomingContext(ctx context.Context) context.Context {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if ids := md.Get(RequestIDMetadataKey); len(ids) > 0 && requestIDRe.MatchString(ids[0]) {
+		return NewContext(ctx, ids[0])
+	}
+	return ctx
+}
+
+// logRPC logs a call to method, that started at start and returned err. Successful calls are only
+// logged at debug level, the API logging every request already, and server errors as errors.
+func logRPC(ctx context.Context, method string, start time.Time, err error) {
+	code := status.Code(err)
+	lvl := slog.LevelDebug
+	switch code {
+	case codes.OK:
+	case codes.Internal, codes.Unknown, codes.DataLoss, codes.Unavailable:
+		lvl = slog.LevelError
+	default:
+		lvl = slog.LevelWarn
+	}
+	attrs := []any{"method", method, "code", code.String(), "duration_ms", time.Since(start).Milliseconds()}
+	if p, ok := identity.FromIncomingContext(ctx); ok {
+		attrs = append(attrs, "user_id", p.UserID, "workspace_id", p.WorkspaceID)
+	}
+	if err != nil {
+		attrs = append(attrs, "error", status.Convert(err).Message())
+	}
+	slog.Log(ctx, lvl, "rpc", attrs...)
+}
+
+// UnaryClientInterceptor forwards the request ID of the call's context as gRPC metadata.
+func UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		return invoker(outgoingContext(ctx), method, req, reply, cc, opts...)
+	}
+}
+
+// StreamClientInterceptor forwards the request ID of the stream's context as gRPC metadata.
+func StreamClientInterceptor() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		return streamer(outgoingContext(ctx), desc, cc, method, opts...)
+	}
+}
+
+// UnaryServerInterceptor puts the forwarded request ID into the context of unary RPCs, so the
+// handlers' log lines carry it, and logs the calls.
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		start := time.Now()
+		ctx = incomingContext(ctx)
+		resp, err := handler(ctx, req)
+		logRPC(ctx, info.FullMethod, start, err)
+		return resp, err
+	}
+}
+
+// StreamServerInterceptor puts the forwarded request ID into the context of streaming RPCs and logs the calls.
+func StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		ctx := incomingContext(ss.Context())
+		err := handler(srv, &contextStream{ServerStream: ss, ctx: ctx})
+		logRPC(ctx, info.FullMethod, start, err)
+		return err
+	}
+}
+
+// contextStream is a server stream with a replaced context.
+type contextStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextStream) Context() context.Context {
+	return s.ctx
+}
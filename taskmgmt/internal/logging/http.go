@@ -0,0 +1,57 @@
+package logging
+
+import (
+	"log/slog"
+	"net/http"
+	"regexp"
+	"runtime/debug"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// requestIDRe matches the request IDs accepted from clients, others being replaced by generated ones.
+var requestIDRe = regexp.MustCompile(`^[A-Za-z0-9._:-]{1,128}$`)
+
+// GinMiddleware is a Gin middleware assigning the request its ID, returned in the X-Request-ID
+// response header, and logging the request once handled. Server errors are logged as errors.
+func GinMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		id := c.GetHeader(RequestIDHeader)
+		if !requestIDRe.MatchString(id) {
+			id = uuid.New().String()
+		}
+		c.Header(RequestIDHeader, id)
+		ctx := NewContext(c.Request.Context(), id)
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+
+		lvl := slog.LevelInfo
+		if c.Writer.Status() >= http.StatusInternalServerError {
+			lvl = slog.LevelError
+		}
+		slog.Log(ctx, lvl, "request",
+			"method", c.Request.Method,
+			"route", c.FullPath(),
+			"path", c.Request.URL.Path,
+			"status", c.Writer.Status(),
+			"duration_ms", time.Since(start).Milliseconds(),
+			"client_ip", c.ClientIP())
+	}
+}
+
+// Recovery is a Gin middleware turning panics into 500 responses, logging them with their stack.
+// It must run after GinMiddleware, so the log line carries the request ID.
+func Recovery() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		defer func() {
+			if r := recover(); r != nil {
+				slog.ErrorContext(c.Request.Context(), "panic while handling request", "panic", r, "stack", string(debug.Stack()))
+				c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+			}
+		}()
+		c.Next()
+	}
+}
@@ -0,0 +1,50 @@
+package logging
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"log/slog"
+	"os"
+	"strings"
+	"time"
+)
+
+// WatchLevelFile applies the log level named in the file at path, such as a key of a mounted
+// Kubernetes ConfigMap, so a change reaches every replica. The file is read right away and then every
+// interval until ctx is done, and its level is applied whenever its content changes, so a level set
+// at runtime on a single replica stays in effect until the file changes. A missing file leaves the
+// level alone.
+func WatchLevelFile(ctx context.Context, path string, interval time.Duration) {
+	last := ""
+	apply := func() {
+		b, err := os.ReadFile(path)
+		if err != nil {
+			if !errors.Is(err, fs.ErrNotExist) {
+				slog.ErrorContext(ctx, "failed to read log level file", "path", path, "error", err)
+			}
+			return
+		}
+		name := strings.TrimSpace(string(b))
+		if name == last {
+			return
+		}
+		last = name
+		if err := SetLevel(name); err != nil {
+			slog.ErrorContext(ctx, "invalid log level file", "path", path, "error", err)
+			return
+		}
+		slog.WarnContext(ctx, "log level changed", "level", Level(), "path", path)
+	}
+	apply()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			apply()
+		}
+	}
+}
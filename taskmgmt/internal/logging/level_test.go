@@ -0,0 +1,51 @@
+package logging
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestWatchLevelFile(t *testing.T) {
+	t.Cleanup(func() { _ = SetLevel("info") })
+	path := filepath.Join(t.TempDir(), "level")
+	write := func(level string) {
+		t.Helper()
+		if err := os.WriteFile(path, []byte(level+"\n"), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	waitFor := func(want string) {
+		t.Helper()
+		for deadline := time.Now().Add(time.Second); Level() != want; time.Sleep(time.Millisecond) {
+			if time.Now().After(deadline) {
+				t.Fatalf("level is %q, want %q", Level(), want)
+			}
+		}
+	}
+
+	_ = SetLevel("info")
+	write("debug")
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go WatchLevelFile(ctx, path, 5*time.Millisecond)
+	waitFor("debug")
+
+	// a level set at runtime is kept until the file changes
+	_ = SetLevel("error")
+	time.Sleep(20 * time.Millisecond)
+	if Level() != "error" {
+		t.Fatalf("unchanged file reset the runtime level to %q", Level())
+	}
+	write("warn")
+	waitFor("warn")
+
+	// an invalid level is ignored
+	write("loud")
+	time.Sleep(20 * time.Millisecond)
+	if Level() != "warn" {
+		t.Errorf("invalid level file changed the level to %q", Level())
+	}
+}
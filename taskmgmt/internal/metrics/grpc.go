@@ -0,0 +1,49 @@
+package metrics
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// SplitMethod splits a full method name, e.g. /task.TaskService/GetTask, into its service and
+// method labels.
+func SplitMethod(fullMethod string) (service, method string) {
+	if i := strings.LastIndex(fullMethod, "/"); i > 0 {
+		return fullMethod[1:i], fullMethod[i+1:]
+	}
+	return "unknown", fullMethod
+}
+
+// observeRPC records GRPCRequests and GRPCRequestDuration for a call to fullMethod that started at
+// start and returned err.
+func observeRPC(fullMethod string, start time.Time, err error) {
+	service, method := SplitMethod(fullMethod)
+	code := status.Code(err).String()
+	GRPCRequests.WithLabelValues(service, method, code).Inc()
+	GRPCRequestDuration.WithLabelValues(service, method, code).Observe(time.Since(start).Seconds())
+}
+
+// UnaryServerInterceptor records the metrics of unary RPCs. Chained first, it also counts the calls
+// rejected by later interceptors.
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		observeRPC(info.FullMethod, start, err)
+		return resp, err
+	}
+}
+
+// StreamServerInterceptor records the metrics of streaming RPCs, their duration spanning the whole stream.
+func StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		err := handler(srv, ss)
+		observeRPC(info.FullMethod, start, err)
+		return err
+	}
+}
@@ -0,0 +1,25 @@
+package metrics
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// HTTPMiddleware is a Gin middleware recording HTTPRequests and HTTPRequestDuration.
+// Requests are labeled with their route's pattern rather than their path, keeping the number of
+// series bounded.
+func HTTPMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+		labels := []string{c.Request.Method, route, strconv.Itoa(c.Writer.Status())}
+		HTTPRequests.WithLabelValues(labels...).Inc()
+		HTTPRequestDuration.WithLabelValues(labels...).Observe(time.Since(start).Seconds())
+	}
+}
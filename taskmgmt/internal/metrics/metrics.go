@@ -0,0 +1,136 @@
+// Package metrics defines the Prometheus metrics of the API and the backend, and the middleware,
+// interceptors and monitors recording them. Metric names and labels are part of the service's
+// interface, used by dashboards, alerts and autoscaling: don't rename them or change their labels.
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "taskmgmt"
+
+var (
+	// HTTPRequests counts the API's HTTP requests.
+	// Labels: method (HTTP method), route (the route's pattern, e.g. /tasks/:id, or "unmatched"),
+	// status (the HTTP status code).
+	HTTPRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "http_requests_total",
+		Help:      "HTTP requests handled by the API, by method, route and status code.",
+	}, []string{"method", "route", "status"})
+
+	// HTTPRequestDuration observes the latency of the API's HTTP requests, in seconds.
+	// Labels: as HTTPRequests.
+	HTTPRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "Latency of HTTP requests handled by the API, by method, route and status code.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+
+	// GRPCRequests counts the backend's RPCs.
+	// Labels: service (the gRPC service, e.g. task.TaskService), method (the RPC, e.g. GetTask),
+	// code (the gRPC status code, e.g. OK or NotFound).
+	GRPCRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "grpc_server_requests_total",
+		Help:      "RPCs handled by the backend, by service, method and status code.",
+	}, []string{"service", "method", "code"})
+
+	// GRPCRequestDuration observes the latency of the backend's RPCs, in seconds.
+	// Labels: as GRPCRequests.
+	GRPCRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "grpc_server_request_duration_seconds",
+		Help:      "Latency of RPCs handled by the backend, by service, method and status code.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"service", "method", "code"})
+
+	// MongoCommandDuration observes the latency of the backend's MongoDB commands, in seconds.
+	// Labels: command (e.g. find, insert or aggregate), outcome ("success" or "failure").
+	MongoCommandDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "mongo_command_duration_seconds",
+		Help:      "Latency of MongoDB commands run by the backend, by command and outcome.",
+		Buckets:   []float64{.0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5},
+	}, []string{"command", "outcome"})
+
+	// MongoPoolConnections is the number of connections in the backend's MongoDB connection pools.
+	// Labels: state ("open" for all connections, "in_use" for those checked out by an operation).
+	MongoPoolConnections = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "mongo_pool_connections",
+		Help:      "Connections of the backend's MongoDB connection pools, by state.",
+	}, []string{"state"})
+
+	// MongoPoolCheckoutFailures counts failures to check a connection out of the MongoDB pools.
+	// Labels: reason (as reported by the driver, e.g. timeout or connectionError).
+	MongoPoolCheckoutFailures = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "mongo_pool_checkout_failures_total",
+		Help:      "Failures to check a connection out of the backend's MongoDB connection pools, by reason.",
+	}, []string{"reason"})
+
+	// GRPCClientRetries counts the API's extra attempts at backend calls.
+	// Labels: service and method (as GRPCRequests), kind ("retry" after a failed attempt, "hedge" for an
+	// attempt started while earlier ones were still running).
+	GRPCClientRetries = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "grpc_client_retries_total",
+		Help:      "Extra attempts made by the API at backend calls, by service, method and kind.",
+	}, []string{"service", "method", "kind"})
+
+	// CircuitBreakerState is the state of the API's circuit breaker on backend calls: 0 closed,
+	// 1 half-open (probing the backend), 2 open (failing calls fast).
+	CircuitBreakerState = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "circuit_breaker_state",
+		Help:      "State of the API's circuit breaker on backend calls: 0 closed, 1 half-open, 2 open.",
+	})
+
+	// CircuitBreakerRejections counts the backend calls failed fast by the open circuit breaker.
+	// Labels: service and method (as GRPCRequests).
+	CircuitBreakerRejections = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "circuit_breaker_rejections_total",
+		Help:      "Backend calls failed fast by the API's open circuit breaker, by service and method.",
+	}, []string{"service", "method"})
+
+	// ConcurrencyLimit is the number of RPCs the backend currently runs at once before shedding load,
+	// adapted to its latency.
+	ConcurrencyLimit = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "concurrency_limit",
+		Help:      "RPCs the backend currently runs at once before shedding load.",
+	})
+
+	// ConcurrencyInFlight is the number of limited RPCs the backend is running.
+	ConcurrencyInFlight = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "concurrency_in_flight",
+		Help:      "Limited RPCs the backend is running.",
+	})
+
+	// LoadShed counts the RPCs the backend rejected as over its concurrency limit.
+	// Labels: service and method (as GRPCRequests), priority ("high" or "low").
+	LoadShed = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "load_shed_total",
+		Help:      "RPCs rejected by the backend as over its concurrency limit, by service, method and priority.",
+	}, []string{"service", "method", "priority"})
+)
+
+// TasksDesc describes the number of tasks stored, collected by the backend from MongoDB.
+// Labels: workspace (the workspace ID), status (the task's board status, "" if unset),
+// completed ("true" or "false").
+var TasksDesc = prometheus.NewDesc(namespace+"_tasks", "Tasks stored, by workspace, status and completion.",
+	[]string{"workspace", "status", "completed"}, nil)
+
+// Handler serves the metrics in the Prometheus exposition format.
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
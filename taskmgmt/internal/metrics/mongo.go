@@ -0,0 +1,42 @@
+package metrics
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/event"
+)
+
+// MongoCommandMonitor returns a MongoDB command monitor recording MongoCommandDuration.
+func MongoCommandMonitor() *event.CommandMonitor {
+	return &event.CommandMonitor{
+		Succeeded: func(_ context.Context, e *event.CommandSucceededEvent) {
+			MongoCommandDuration.WithLabelValues(e.CommandName, "success").Observe(e.Duration.Seconds())
+		},
+		Failed: func(_ context.Context, e *event.CommandFailedEvent) {
+			MongoCommandDuration.WithLabelValues(e.CommandName, "failure").Observe(e.Duration.Seconds())
+		},
+	}
+}
+
+// MongoPoolMonitor returns a MongoDB connection pool monitor recording MongoPoolConnections and
+// MongoPoolCheckoutFailures.
+func MongoPoolMonitor() *event.PoolMonitor {
+	open := MongoPoolConnections.WithLabelValues("open")
+	inUse := MongoPoolConnections.WithLabelValues("in_use")
+	return &event.PoolMonitor{
+		Event: func(e *event.PoolEvent) {
+			switch e.Type {
+			case event.ConnectionCreated:
+				open.Inc()
+			case event.ConnectionClosed:
+				open.Dec()
+			case event.GetSucceeded:
+				inUse.Inc()
+			case event.ConnectionReturned:
+				inUse.Dec()
+			case event.GetFailed:
+				MongoPoolCheckoutFailures.WithLabelValues(e.Reason).Inc()
+			}
+		},
+	}
+}
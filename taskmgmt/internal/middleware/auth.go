@@ -1,14 +1,30 @@
 package middleware
 
 import (
+	"errors"
 	"net/http"
 	"strings"
 
+	"github.com/Cohen-J-Omer/k8-task-mgmt-system/taskmgmt/internal/auth"
+	"github.com/Cohen-J-Omer/k8-task-mgmt-system/taskmgmt/internal/identity"
+	"github.com/Cohen-J-Omer/k8-task-mgmt-system/taskmgmt/internal/rbac"
 	"github.com/gin-gonic/gin"
 )
 
+// UserIDHeader identifies the user a holder of the bootstrap admin key acts on behalf of, with the
+// user's own roles.
+const UserIDHeader = "X-User-ID"
+
+// WorkspaceHeader selects the workspace an operator acts in.
+const WorkspaceHeader = "X-Workspace-ID"
+
+// PrincipalKey is the Gin context key the authenticated principal is stored under.
+const PrincipalKey = "principal"
+
 // AuthMiddleware is a Gin middleware that checks for a valid Bearer token in the Authorization header.
-func AuthMiddleware(expectedToken string) gin.HandlerFunc {
+// The token is resolved to the calling principal, which is stored in the Gin context and in the request's
+// context, from which it is forwarded to the backend.
+func AuthMiddleware(authenticator auth.Authenticator, users auth.UserResolver) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		authHeader := c.GetHeader("Authorization")
 		if !strings.HasPrefix(authHeader, "Bearer ") {
@@ -16,10 +32,48 @@ func AuthMiddleware(expectedToken string) gin.HandlerFunc {
 			return
 		}
 		token := strings.TrimPrefix(authHeader, "Bearer ")
-		if token != expectedToken {
+		principal, err := authenticator.Authenticate(c.Request.Context(), token)
+		if errors.Is(err, auth.ErrInvalidCredentials) {
 			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid token"})
 			return
 		}
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusServiceUnavailable, gin.H{"error": "failed to authenticate"})
+			return
+		}
+		// operators may act in another workspace, and the bootstrap admin key on behalf of a user of it
+		if workspace := c.GetHeader(WorkspaceHeader); workspace != "" && rbac.Operator(principal) {
+			p := *principal
+			p.WorkspaceID = workspace
+			principal = &p
+		}
+		if userID := c.GetHeader(UserIDHeader); userID != "" && principal.Bootstrap {
+			user, err := users.Resolve(identity.NewContext(c.Request.Context(), principal), userID)
+			if errors.Is(err, auth.ErrUnknownUser) {
+				c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "unknown user in " + UserIDHeader + " header"})
+				return
+			}
+			if err != nil {
+				c.AbortWithStatusJSON(http.StatusServiceUnavailable, gin.H{"error": "failed to authenticate"})
+				return
+			}
+			principal = user
+		}
+		c.Set(PrincipalKey, principal)
+		c.Request = c.Request.WithContext(identity.NewContext(c.Request.Context(), principal))
+		c.Next()
+	}
+}
+
+// RequirePermission is a Gin middleware that rejects callers whose roles don't grant the permission,
+// naming the missing permission. It must run after AuthMiddleware.
+func RequirePermission(perm rbac.Permission) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		p, ok := identity.FromContext(c.Request.Context())
+		if !ok || !rbac.Allowed(p.Roles, perm) {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "missing permission " + string(perm), "permission": perm})
+			return
+		}
 		c.Next()
 	}
 }
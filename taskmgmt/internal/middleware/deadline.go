@@ -0,0 +1,90 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Cohen-J-Omer/k8-task-mgmt-system/taskmgmt/internal/resilience"
+	"github.com/gin-gonic/gin"
+)
+
+// TimeoutHeader lets clients request a deadline for their request, either in seconds, e.g. "1.5",
+// or as a duration, e.g. "1500ms".
+const TimeoutHeader = "X-Request-Timeout"
+
+// Timeouts configures the deadlines of requests.
+type Timeouts struct {
+	// Default applies to routes missing from Routes.
+	Default time.Duration
+	// Routes maps a route's method and pattern, e.g. "GET /tasks/:id", to its timeout.
+	// A zero timeout leaves the route's requests without deadline, unless clients request one.
+	Routes map[string]time.Duration
+	// Max caps the timeouts requested by clients.
+	Max time.Duration
+}
+
+// ParseRouteTimeouts parses route timeouts listed as "METHOD /pattern=duration" entries, e.g.
+// "GET /tasks=5s", adding them to and overriding those of defaults.
+func ParseRouteTimeouts(entries []string, defaults map[string]time.Duration) (map[string]time.Duration, error) {
+	routes := make(map[string]time.Duration, len(defaults)+len(entries))
+	for route, d := range defaults {
+		routes[route] = d
+	}
+	for _, entry := range entries {
+		route, value, ok := strings.Cut(entry, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid route timeout %q, expected METHOD /pattern=duration", entry)
+		}
+		d, err := time.ParseDuration(strings.TrimSpace(value))
+		if err != nil || d < 0 {
+			return nil, fmt.Errorf("invalid route timeout %q, expected METHOD /pattern=duration", entry)
+		}
+		routes[strings.Join(strings.Fields(route), " ")] = d
+	}
+	return routes, nil
+}
+
+// parseTimeout parses the value of TimeoutHeader.
+func parseTimeout(value string) (time.Duration, error) {
+	if seconds, err := strconv.ParseFloat(value, 64); err == nil {
+		return time.Duration(seconds * float64(time.Second)), nil
+	}
+	return time.ParseDuration(value)
+}
+
+// Deadline is a Gin middleware setting the deadline of the request's context, which propagates to the
+// backend calls made with it: the timeout requested in TimeoutHeader, capped at t.Max, or else the
+// route's configured timeout. A requested timeout shorter than the route's is marked with
+// resilience.WithClientDeadline, so backend calls exceeding it don't open the circuit breaker.
+func Deadline(t Timeouts) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		timeout, ok := t.Routes[c.Request.Method+" "+c.FullPath()]
+		if !ok {
+			timeout = t.Default
+		}
+		ctx := c.Request.Context()
+		if value := c.GetHeader(TimeoutHeader); value != "" {
+			requested, err := parseTimeout(value)
+			if err != nil || requested <= 0 {
+				c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "invalid " + TimeoutHeader + " header, expected a positive number of seconds or a duration such as 500ms"})
+				return
+			}
+			if timeout <= 0 || requested < timeout {
+				ctx = resilience.WithClientDeadline(ctx)
+			}
+			timeout = min(requested, t.Max)
+		}
+		if timeout <= 0 {
+			c.Next()
+			return
+		}
+		ctx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+	}
+}
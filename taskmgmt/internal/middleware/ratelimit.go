@@ -0,0 +1,73 @@
+package middleware
+
+import (
+	"log/slog"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Cohen-J-Omer/k8-task-mgmt-system/taskmgmt/internal/identity"
+	"github.com/Cohen-J-Omer/k8-task-mgmt-system/taskmgmt/internal/ratelimit"
+	"github.com/gin-gonic/gin"
+)
+
+// seconds rounds d up to whole seconds, as rate limiting headers count them.
+func seconds(d time.Duration) string {
+	return strconv.Itoa(int(math.Ceil(d.Seconds())))
+}
+
+// RateLimit is a Gin middleware limiting the rate of each caller's requests, keyed by the principal
+// stored by AuthMiddleware, or its API key if limited separately, or by the client's IP for anonymous
+// requests. Responses carry the
+// RateLimit-Limit, RateLimit-Remaining, RateLimit-Reset and RateLimit-Policy headers, and requests
+// over the limit get 429 with Retry-After. Requests are let through if the store fails.
+func RateLimit(policy ratelimit.Policy, store ratelimit.Store) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		p, ok := identity.FromContext(c.Request.Context())
+		key := "ip:" + c.ClientIP()
+		if ok {
+			key = policy.BucketKey(p)
+		} else {
+			p = nil
+		}
+		if take(c, store, key, policy.For(p)) {
+			c.Next()
+		}
+	}
+}
+
+// RateLimitIP is a Gin middleware limiting the rate of requests from each client IP, as RateLimit
+// does. Run before AuthMiddleware, it limits requests failing authentication too, e.g. floods of
+// guessed tokens or API keys, which RateLimit never sees.
+func RateLimitIP(limit ratelimit.Limit, store ratelimit.Store) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if take(c, store, "ip:"+c.ClientIP(), limit) {
+			c.Next()
+		}
+	}
+}
+
+// take takes a token from the key's bucket, setting the rate limiting headers, and reports whether
+// the request may proceed, having aborted it otherwise.
+func take(c *gin.Context, store ratelimit.Store, key string, limit ratelimit.Limit) bool {
+	if !limit.Enabled() {
+		return true
+	}
+	decision, err := store.Take(c.Request.Context(), key, limit)
+	if err != nil {
+		slog.WarnContext(c.Request.Context(), "failed to rate limit request, letting it through", "key", key, "error", err)
+		return true
+	}
+	c.Header("RateLimit-Limit", strconv.Itoa(limit.Burst))
+	c.Header("RateLimit-Remaining", strconv.Itoa(decision.Remaining))
+	c.Header("RateLimit-Reset", seconds(decision.Reset))
+	window := time.Duration(float64(limit.Burst) / limit.Rate * float64(time.Second))
+	c.Header("RateLimit-Policy", strconv.Itoa(limit.Burst)+";w="+seconds(window))
+	if !decision.Allowed {
+		c.Header("Retry-After", seconds(decision.RetryAfter))
+		c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{"error": "rate limit exceeded"})
+		return false
+	}
+	return true
+}
@@ -0,0 +1,193 @@
+// Package mtls provides mutual TLS between the API and the backend. Certificates are read from PEM
+// files, as mounted from Kubernetes secrets, and reloaded when the files change, so they can be
+// rotated without a restart.
+package mtls
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// Config names the PEM files of a TLS identity and of the CA its peers' certificates must chain to.
+type Config struct {
+	CertFile string
+	KeyFile  string
+	CAFile   string
+	// AllowedSANs lists the DNS names, URIs (e.g. SPIFFE IDs) and IP addresses accepted in a peer's
+	// certificate, which must carry one of them. When empty, any certificate signed by the CA is accepted.
+	AllowedSANs []string
+	// ReloadInterval is how often the files are checked for changes, at most.
+	ReloadInterval time.Duration
+}
+
+// Enabled reports whether any file is configured. Either all of them or none must be.
+func (c Config) Enabled() bool {
+	return c.CertFile != "" || c.KeyFile != "" || c.CAFile != ""
+}
+
+// Reloader holds a TLS identity and CA pool loaded from files, reloading them during handshakes once
+// the files changed. If reloading fails, the previously loaded certificates keep being used.
+type Reloader struct {
+	cfg Config
+
+	mu      sync.Mutex
+	cert    *tls.Certificate
+	pool    *x509.CertPool
+	modTime time.Time // latest modification time of the files when they were loaded
+	checked time.Time
+}
+
+// NewReloader loads the files named by cfg.
+func NewReloader(cfg Config) (*Reloader, error) {
+	if cfg.CertFile == "" || cfg.KeyFile == "" || cfg.CAFile == "" {
+		return nil, errors.New("a certificate, key and CA file are all required for mutual TLS")
+	}
+	r := &Reloader{cfg: cfg}
+	modTime, err := r.filesModTime()
+	if err != nil {
+		return nil, err
+	}
+	if err := r.load(modTime); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// filesModTime returns the latest modification time of the files.
+func (r *Reloader) filesModTime() (time.Time, error) {
+	var latest time.Time
+	for _, name := range []string{r.cfg.CertFile, r.cfg.KeyFile, r.cfg.CAFile} {
+		info, err := os.Stat(name)
+		if err != nil {
+			return time.Time{}, err
+		}
+		if info.ModTime().After(latest) {
+			latest = info.ModTime()
+		}
+	}
+	return latest, nil
+}
+
+// load parses the files, replacing the loaded certificates.
+func (r *Reloader) load(modTime time.Time) error {
+	cert, err := tls.LoadX509KeyPair(r.cfg.CertFile, r.cfg.KeyFile)
+	if err != nil {
+		return fmt.Errorf("failed to load TLS certificate: %w", err)
+	}
+	caPEM, err := os.ReadFile(r.cfg.CAFile)
+	if err != nil {
+		return fmt.Errorf("failed to load TLS CA: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(caPEM) {
+		return fmt.Errorf("no certificates found in %s", r.cfg.CAFile)
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.cert, r.pool, r.modTime = &cert, pool, modTime
+	return nil
+}
+
+// current returns the loaded certificate and CA pool, first reloading them if the files changed.
+func (r *Reloader) current() (*tls.Certificate, *x509.CertPool) {
+	r.mu.Lock()
+	stale := time.Since(r.checked) >= r.cfg.ReloadInterval
+	if stale {
+		r.checked = time.Now()
+	}
+	loaded := r.modTime
+	r.mu.Unlock()
+	if stale {
+		// a secret being rotated may be seen half written, in which case the next check retries
+		if modTime, err := r.filesModTime(); err == nil && !modTime.Equal(loaded) {
+			_ = r.load(modTime)
+		}
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.cert, r.pool
+}
+
+// ServerConfig returns the TLS configuration of a server requiring clients to present a certificate
+// signed by the CA and carrying one of the allowed SANs.
+func (r *Reloader) ServerConfig() *tls.Config {
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			cert, pool := r.current()
+			return &tls.Config{
+				MinVersion:   tls.VersionTLS12,
+				Certificates: []tls.Certificate{*cert},
+				ClientCAs:    pool,
+				ClientAuth:   tls.RequireAndVerifyClientCert,
+				VerifyConnection: func(cs tls.ConnectionState) error {
+					return r.verifySAN(cs.PeerCertificates[0])
+				},
+			}, nil
+		},
+	}
+}
+
+// ClientConfig returns the TLS configuration of a client presenting its certificate and verifying the
+// server's against the CA. The server's certificate must be valid for serverName, or for the address
+// dialed if serverName is empty, and carry one of the allowed SANs.
+func (r *Reloader) ClientConfig(serverName string) *tls.Config {
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		ServerName: serverName,
+		GetClientCertificate: func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			cert, _ := r.current()
+			return cert, nil
+		},
+		// the server's certificate is verified below against the current CA pool, which may have been
+		// reloaded since this configuration was created
+		InsecureSkipVerify: true,
+		VerifyConnection: func(cs tls.ConnectionState) error {
+			_, pool := r.current()
+			if len(cs.PeerCertificates) == 0 {
+				return errors.New("server presented no certificate")
+			}
+			intermediates := x509.NewCertPool()
+			for _, cert := range cs.PeerCertificates[1:] {
+				intermediates.AddCert(cert)
+			}
+			_, err := cs.PeerCertificates[0].Verify(x509.VerifyOptions{
+				DNSName:       cs.ServerName,
+				Roots:         pool,
+				Intermediates: intermediates,
+			})
+			if err != nil {
+				return err
+			}
+			return r.verifySAN(cs.PeerCertificates[0])
+		},
+	}
+}
+
+// verifySAN fails unless the certificate carries one of the allowed SANs.
+func (r *Reloader) verifySAN(cert *x509.Certificate) error {
+	if len(r.cfg.AllowedSANs) == 0 {
+		return nil
+	}
+	var sans []string
+	sans = append(sans, cert.DNSNames...)
+	for _, uri := range cert.URIs {
+		sans = append(sans, uri.String())
+	}
+	for _, ip := range cert.IPAddresses {
+		sans = append(sans, ip.String())
+	}
+	for _, san := range sans {
+		for _, allowed := range r.cfg.AllowedSANs {
+			if san == allowed {
+				return nil
+			}
+		}
+	}
+	return fmt.Errorf("peer certificate SANs %v are not allowed", sans)
+}
@@ -0,0 +1,233 @@
+package mtls
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"io"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// testCA is a certificate authority generated for a test.
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	pem  []byte
+}
+
+func newTestCA(t *testing.T) *testCA {
+	t.Helper()
+	key := newKey(t)
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "taskmgmt test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("failed to create CA certificate: %v", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("failed to parse CA certificate: %v", err)
+	}
+	return &testCA{cert: cert, key: key, pem: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})}
+}
+
+func newKey(t *testing.T) *ecdsa.PrivateKey {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	return key
+}
+
+// issue writes a certificate for the DNS name signed by the CA, its key and the CA's certificate to
+// dir, returning the configuration naming them.
+func (ca *testCA) issue(t *testing.T, dir, dnsName string, serial int64) Config {
+	t.Helper()
+	key := newKey(t)
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: dnsName},
+		DNSNames:     []string{dnsName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, ca.cert, &key.PublicKey, ca.key)
+	if err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("failed to marshal key: %v", err)
+	}
+	cfg := Config{
+		CertFile: filepath.Join(dir, "tls.crt"),
+		KeyFile:  filepath.Join(dir, "tls.key"),
+		CAFile:   filepath.Join(dir, "ca.crt"),
+	}
+	writeFile(t, cfg.CertFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+	writeFile(t, cfg.KeyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}))
+	writeFile(t, cfg.CAFile, ca.pem)
+	return cfg
+}
+
+func writeFile(t *testing.T, name string, data []byte) {
+	t.Helper()
+	if err := os.WriteFile(name, data, 0o600); err != nil {
+		t.Fatalf("failed to write %s: %v", name, err)
+	}
+}
+
+func newTestReloader(t *testing.T, cfg Config) *Reloader {
+	t.Helper()
+	r, err := NewReloader(cfg)
+	if err != nil {
+		t.Fatalf("NewReloader: %v", err)
+	}
+	return r
+}
+
+// handshake runs a TLS handshake between the configurations over a loopback connection, returning the
+// client's and server's errors.
+func handshake(t *testing.T, client, server *tls.Config) (clientErr, serverErr error) {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	defer ln.Close()
+	done := make(chan error, 1)
+	go func() {
+		raw, err := ln.Accept()
+		if err != nil {
+			done <- err
+			return
+		}
+		conn := tls.Server(raw, server)
+		err = conn.Handshake()
+		_ = conn.Close()
+		done <- err
+	}()
+	raw, err := net.Dial("tcp", ln.Addr().String())
+	if err != nil {
+		t.Fatalf("failed to dial: %v", err)
+	}
+	defer raw.Close()
+	conn := tls.Client(raw, client)
+	_ = conn.SetDeadline(time.Now().Add(5 * time.Second))
+	clientErr = conn.Handshake()
+	if clientErr == nil {
+		// the client's handshake completes before the server verified its certificate, which a read reports
+		_, clientErr = conn.Read(make([]byte, 1))
+		if errors.Is(clientErr, io.EOF) {
+			clientErr = nil
+		}
+	}
+	_ = conn.Close()
+	return clientErr, <-done
+}
+
+func TestMutualTLS(t *testing.T) {
+	ca := newTestCA(t)
+	serverCfg := ca.issue(t, t.TempDir(), "backend", 2)
+	clientCfg := ca.issue(t, t.TempDir(), "api", 3)
+
+	tests := []struct {
+		name          string
+		serverSANs    []string
+		clientSANs    []string
+		clientCA      *testCA // CA of the client's certificate, ca if nil
+		wantClientErr bool
+		wantServerErr bool
+	}{
+		{name: "any certificate signed by the CA"},
+		{name: "allowed SANs", serverSANs: []string{"api"}, clientSANs: []string{"backend"}},
+		{name: "client SAN not allowed", serverSANs: []string{"other"}, wantServerErr: true, wantClientErr: true},
+		{name: "server SAN not allowed", clientSANs: []string{"other"}, wantClientErr: true, wantServerErr: true},
+		{name: "client signed by another CA", clientCA: newTestCA(t), wantServerErr: true, wantClientErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, client := serverCfg, clientCfg
+			if tt.clientCA != nil {
+				client = tt.clientCA.issue(t, t.TempDir(), "api", 4)
+				// the client still trusts the server's CA
+				writeFile(t, client.CAFile, ca.pem)
+			}
+			server.AllowedSANs, client.AllowedSANs = tt.serverSANs, tt.clientSANs
+
+			clientErr, serverErr := handshake(t,
+				newTestReloader(t, client).ClientConfig("backend"),
+				newTestReloader(t, server).ServerConfig())
+			if (clientErr != nil) != tt.wantClientErr {
+				t.Errorf("client error = %v, want error: %v", clientErr, tt.wantClientErr)
+			}
+			if (serverErr != nil) != tt.wantServerErr {
+				t.Errorf("server error = %v, want error: %v", serverErr, tt.wantServerErr)
+			}
+		})
+	}
+}
+
+func TestClientVerifiesServerName(t *testing.T) {
+	ca := newTestCA(t)
+	server := newTestReloader(t, ca.issue(t, t.TempDir(), "backend", 2))
+	client := newTestReloader(t, ca.issue(t, t.TempDir(), "api", 3))
+
+	clientErr, _ := handshake(t, client.ClientConfig("not-the-backend"), server.ServerConfig())
+	if clientErr == nil {
+		t.Error("client accepted a certificate for another name")
+	}
+}
+
+func TestReloaderPicksUpRotatedFiles(t *testing.T) {
+	oldCA, newCA := newTestCA(t), newTestCA(t)
+	serverDir := t.TempDir()
+	serverCfg := oldCA.issue(t, serverDir, "backend", 2)
+	serverCfg.ReloadInterval = 0
+	server := newTestReloader(t, serverCfg)
+	client := newTestReloader(t, newCA.issue(t, t.TempDir(), "api", 3))
+
+	if _, serverErr := handshake(t, client.ClientConfig("backend"), server.ServerConfig()); serverErr == nil {
+		t.Fatal("server accepted a client of a CA it doesn't trust yet")
+	}
+
+	// rotate the server to the new CA, with a later modification time than the files replaced
+	newCA.issue(t, serverDir, "backend", 4)
+	later := time.Now().Add(time.Minute)
+	for _, name := range []string{serverCfg.CertFile, serverCfg.KeyFile, serverCfg.CAFile} {
+		if err := os.Chtimes(name, later, later); err != nil {
+			t.Fatalf("failed to touch %s: %v", name, err)
+		}
+	}
+
+	clientErr, serverErr := handshake(t, client.ClientConfig("backend"), server.ServerConfig())
+	if clientErr != nil || serverErr != nil {
+		t.Errorf("handshake after rotation failed: client %v, server %v", clientErr, serverErr)
+	}
+}
+
+func TestNewReloaderRequiresAllFiles(t *testing.T) {
+	if _, err := NewReloader(Config{CertFile: "tls.crt", KeyFile: "tls.key"}); err == nil {
+		t.Error("NewReloader accepted a configuration without a CA")
+	}
+}
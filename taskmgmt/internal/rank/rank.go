@@ -0,0 +1,89 @@
+// Package rank generates fractional ranks: strings ordering items, such as the cards of a board column,
+// where an item can always be placed between two others by giving it a new rank, without renumbering
+// the rest. Ranks are base 36 fractions, compared as plain strings.
+package rank
+
+import (
+	"errors"
+	"strings"
+)
+
+const digits = "0123456789abcdefghijklmnopqrstuvwxyz"
+
+// ErrInvalid reports ranks that aren't valid or aren't in order.
+var ErrInvalid = errors.New("invalid ranks")
+
+// Valid reports whether r is a well-formed rank. Ranks never end in the smallest digit, so that
+// another rank always fits below them.
+func Valid(r string) bool {
+	if r == "" || strings.HasSuffix(r, digits[:1]) {
+		return false
+	}
+	for i := 0; i < len(r); i++ {
+		if strings.IndexByte(digits, r[i]) < 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Between returns a rank sorting strictly after a and before b. An empty a stands for the start
+// and an empty b for the end, so Between("", "") returns a first rank.
+func Between(a, b string) (string, error) {
+	if (a != "" && !Valid(a)) || (b != "" && !Valid(b)) || (a != "" && b != "" && a >= b) {
+		return "", ErrInvalid
+	}
+	return midpoint(a, b), nil
+}
+
+// midpoint returns a rank between the fractions a and b, where a < b and an empty b stands for 1.
+func midpoint(a, b string) string {
+	if b != "" {
+		// keep the common prefix, a being padded with zeros
+		n := 0
+		for n < len(b) && digitAt(a, n) == b[n] {
+			n++
+		}
+		if n > 0 {
+			rest := ""
+			if n < len(a) {
+				rest = a[n:]
+			}
+			return b[:n] + midpoint(rest, b[n:])
+		}
+	}
+	lo := strings.IndexByte(digits, digitAt(a, 0))
+	hi := len(digits)
+	if b != "" {
+		hi = strings.IndexByte(digits, b[0])
+	}
+	if hi-lo > 1 {
+		// step next to the bound when the other side is open, so that repeatedly adding items
+		// at the start or end of a list grows ranks slowly
+		switch {
+		case a == "" && b != "":
+			return string(digits[hi-1])
+		case b == "" && a != "":
+			return string(digits[lo+1])
+		}
+		return string(digits[(lo+hi)/2])
+	}
+	// the first digits are consecutive: b's first digit alone still sorts before b if b is longer,
+	// otherwise extend a below the end
+	if len(b) > 1 {
+		return b[:1]
+	}
+	rest := ""
+	if len(a) > 1 {
+		rest = a[1:]
+	}
+	return string(digits[lo]) + midpoint(rest, "")
+}
+
+// digitAt returns the i-th digit of r, padding it with zeros.
+func digitAt(r string, i int) byte {
+	if i < len(r) {
+		return r[i]
+	}
+	return digits[0]
+}
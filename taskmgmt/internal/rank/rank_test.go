@@ -0,0 +1,95 @@
+package rank
+
+import (
+	"errors"
+	"math/rand/v2"
+	"testing"
+)
+
+func TestBetween(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want string
+	}{
+		{"", "", "i"},
+		{"", "i", "h"},
+		{"i", "", "j"},
+		{"a", "c", "b"},
+		{"a", "b", "ai"},
+		{"", "1", "0i"},
+		{"", "01", "00i"},
+		{"z", "", "zi"},
+		{"ab", "ac", "abi"},
+		{"a", "a1", "a0i"},
+		{"a5", "b", "a6"},
+		{"az", "b", "azi"},
+	}
+	for _, tt := range tests {
+		got, err := Between(tt.a, tt.b)
+		if err != nil {
+			t.Errorf("Between(%q, %q): %v", tt.a, tt.b, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("Between(%q, %q) = %q, want %q", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestBetweenRejectsInvalidRanks(t *testing.T) {
+	for _, tt := range []struct{ a, b string }{
+		{"b", "a"},
+		{"a", "a"},
+		{"a0", ""},
+		{"", "A"},
+		{"a-b", ""},
+	} {
+		if _, err := Between(tt.a, tt.b); !errors.Is(err, ErrInvalid) {
+			t.Errorf("Between(%q, %q) returned %v, want ErrInvalid", tt.a, tt.b, err)
+		}
+	}
+}
+
+// TestBetweenKeepsOrder inserts ranks at random positions of a list, as cards are moved around a
+// column, checking each fits strictly between its neighbours and is valid.
+func TestBetweenKeepsOrder(t *testing.T) {
+	rng := rand.New(rand.NewPCG(1, 2))
+	var ranks []string
+	for i := 0; i < 2000; i++ {
+		pos := rng.IntN(len(ranks) + 1)
+		var a, b string
+		if pos > 0 {
+			a = ranks[pos-1]
+		}
+		if pos < len(ranks) {
+			b = ranks[pos]
+		}
+		r, err := Between(a, b)
+		if err != nil {
+			t.Fatalf("Between(%q, %q): %v", a, b, err)
+		}
+		if !Valid(r) || (a != "" && r <= a) || (b != "" && r >= b) {
+			t.Fatalf("Between(%q, %q) = %q, which isn't a valid rank between them", a, b, r)
+		}
+		ranks = append(ranks[:pos], append([]string{r}, ranks[pos:]...)...)
+	}
+}
+
+// TestBetweenGrowsSlowlyAtTheEnds checks that ranks appended or prepended repeatedly only grow by a
+// digit once the digits between the middle and the end are used up.
+func TestBetweenGrowsSlowlyAtTheEnds(t *testing.T) {
+	first, last := "i", "i"
+	for i := 0; i < 1000; i++ {
+		var err error
+		if first, err = Between("", first); err != nil {
+			t.Fatalf("prepending: %v", err)
+		}
+		if last, err = Between(last, ""); err != nil {
+			t.Fatalf("appending: %v", err)
+		}
+	}
+	// each digit takes 17 or 18 insertions
+	if len(first) > 1000/17+1 || len(last) > 1000/17+1 {
+		t.Errorf("after 1000 insertions at the ends, ranks grew to %d and %d digits", len(first), len(last))
+	}
+}
@@ -0,0 +1,215 @@
+// Package ratelimit limits the rate of each caller's requests with token buckets: a caller's bucket
+// holds up to Burst tokens, refilled at Rate tokens per second, and each request takes one. Buckets
+// are kept in memory by each API replica, or in the backend so limits hold across replicas.
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Cohen-J-Omer/k8-task-mgmt-system/taskmgmt/internal/identity"
+	pb "github.com/Cohen-J-Omer/k8-task-mgmt-system/taskmgmt/proto"
+)
+
+// Limit is the rate and burst of a token bucket.
+type Limit struct {
+	Rate  float64 // tokens added per second
+	Burst int     // capacity
+}
+
+// Enabled reports whether the limit restricts requests at all.
+func (l Limit) Enabled() bool {
+	return l.Rate > 0 && l.Burst > 0
+}
+
+// ParseLimit parses a limit written as "rate:burst", e.g. "10:20".
+func ParseLimit(s string) (Limit, error) {
+	rate, burst, ok := strings.Cut(s, ":")
+	r, err1 := strconv.ParseFloat(strings.TrimSpace(rate), 64)
+	b, err2 := strconv.Atoi(strings.TrimSpace(burst))
+	if !ok || err1 != nil || err2 != nil || r < 0 || b < 0 {
+		return Limit{}, fmt.Errorf("invalid rate limit %q, expected rate:burst, e.g. 10:20", s)
+	}
+	return Limit{Rate: r, Burst: b}, nil
+}
+
+// ParseLimits parses limits listed as "name=rate:burst" entries, e.g. "admin=50:100".
+func ParseLimits(entries []string) (map[string]Limit, error) {
+	limits := make(map[string]Limit, len(entries))
+	for _, entry := range entries {
+		name, value, ok := strings.Cut(entry, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid rate limit %q, expected name=rate:burst", entry)
+		}
+		limit, err := ParseLimit(value)
+		if err != nil {
+			return nil, err
+		}
+		limits[strings.TrimSpace(name)] = limit
+	}
+	return limits, nil
+}
+
+// Policy selects the limit of each caller.
+type Policy struct {
+	Default Limit            // for callers without a more specific limit, and anonymous ones
+	Roles   map[string]Limit // by role, a caller with several roles getting the most generous one
+	Users   map[string]Limit // by user ID, overriding the roles' limits
+	Keys    map[string]Limit // by API key ID, overriding the users' limits
+}
+
+// For returns the limit of the principal, or the default limit for an anonymous caller.
+func (p Policy) For(principal *identity.Principal) Limit {
+	if principal == nil {
+		return p.Default
+	}
+	if l, ok := p.keyLimit(principal); ok {
+		return l
+	}
+	if l, ok := p.Users[principal.UserID]; ok {
+		return l
+	}
+	var limit *Limit
+	for _, role := range principal.Roles {
+		if l, ok := p.Roles[role]; ok && (limit == nil || moreGenerous(l, *limit)) {
+			limit = &l
+		}
+	}
+	if limit == nil {
+		return p.Default
+	}
+	return *limit
+}
+
+// keyLimit returns the limit of the API key the principal authenticated with, if it has one.
+func (p Policy) keyLimit(principal *identity.Principal) (Limit, bool) {
+	if principal.APIKeyID == "" {
+		return Limit{}, false
+	}
+	l, ok := p.Keys[principal.APIKeyID]
+	return l, ok
+}
+
+// BucketKey returns the key of the principal's bucket: the API key's when it has a limit of its own,
+// so each of a user's keys is limited separately, and otherwise the user's, shared by their keys.
+func (p Policy) BucketKey(principal *identity.Principal) string {
+	if _, ok := p.keyLimit(principal); ok {
+		return "apikey:" + principal.APIKeyID
+	}
+	return "user:" + principal.WorkspaceID + "/" + principal.UserID
+}
+
+// moreGenerous reports whether a allows more requests than b, no limit being the most generous.
+func moreGenerous(a, b Limit) bool {
+	if !b.Enabled() {
+		return false
+	}
+	return !a.Enabled() || a.Rate > b.Rate || (a.Rate == b.Rate && a.Burst > b.Burst)
+}
+
+// Decision is the outcome of taking a token from a bucket.
+type Decision struct {
+	Allowed    bool
+	Remaining  int           // whole tokens left
+	Reset      time.Duration // until the bucket is full again
+	RetryAfter time.Duration // until a token is available, 0 if allowed
+}
+
+// Store keeps token buckets by key.
+type Store interface {
+	Take(ctx context.Context, key string, limit Limit) (Decision, error)
+}
+
+// bucket is a token bucket as of its last update.
+type bucket struct {
+	tokens  float64
+	updated time.Time
+	limit   Limit
+}
+
+// refill adds the tokens accrued since the last update.
+func (b *bucket) refill(now time.Time) {
+	b.tokens = math.Min(float64(b.limit.Burst), b.tokens+now.Sub(b.updated).Seconds()*b.limit.Rate)
+	b.updated = now
+}
+
+// LocalStore keeps token buckets in memory, limiting requests to the API replica only.
+type LocalStore struct {
+	mu      sync.Mutex
+	buckets map[string]*bucket
+	swept   time.Time
+}
+
+func NewLocalStore() *LocalStore {
+	return &LocalStore{buckets: make(map[string]*bucket), swept: time.Now()}
+}
+
+// sweepInterval is how often full buckets are forgotten, a full bucket being the same as a missing one.
+const sweepInterval = time.Minute
+
+func (s *LocalStore) Take(_ context.Context, key string, limit Limit) (Decision, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := time.Now()
+	if now.Sub(s.swept) >= sweepInterval {
+		for k, b := range s.buckets {
+			if b.refill(now); b.tokens >= float64(b.limit.Burst) {
+				delete(s.buckets, k)
+			}
+		}
+		s.swept = now
+	}
+	b, ok := s.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(limit.Burst), updated: now}
+		s.buckets[key] = b
+	}
+	// the caller's limit may have changed since, e.g. with their roles
+	b.limit = limit
+	b.refill(now)
+	allowed := b.tokens >= 1
+	if allowed {
+		b.tokens--
+	}
+	return Decide(allowed, b.tokens, limit), nil
+}
+
+// Decide returns the decision for a bucket left with tokens, wherever the bucket is kept.
+func Decide(allowed bool, tokens float64, limit Limit) Decision {
+	d := Decision{
+		Allowed:   allowed,
+		Remaining: int(tokens),
+		Reset:     time.Duration((float64(limit.Burst) - tokens) / limit.Rate * float64(time.Second)),
+	}
+	if !allowed {
+		d.RetryAfter = time.Duration((1 - tokens) / limit.Rate * float64(time.Second))
+	}
+	return d
+}
+
+// BackendStore keeps token buckets in the backend, shared by all API replicas.
+type BackendStore struct {
+	client pb.RateLimitServiceClient
+}
+
+func NewBackendStore(client pb.RateLimitServiceClient) *BackendStore {
+	return &BackendStore{client: client}
+}
+
+func (s *BackendStore) Take(ctx context.Context, key string, limit Limit) (Decision, error) {
+	resp, err := s.client.Take(ctx, &pb.RateLimitRequest{Key: key, Rate: limit.Rate, Burst: int32(limit.Burst)})
+	if err != nil {
+		return Decision{}, err
+	}
+	return Decision{
+		Allowed:    resp.Allowed,
+		Remaining:  int(resp.Remaining),
+		Reset:      time.Duration(resp.ResetMs) * time.Millisecond,
+		RetryAfter: time.Duration(resp.RetryAfterMs) * time.Millisecond,
+	}, nil
+}
@@ -0,0 +1,42 @@
+package ratelimit
+
+import (
+	"testing"
+
+	"github.com/Cohen-J-Omer/k8-task-mgmt-system/taskmgmt/internal/identity"
+)
+
+func TestPolicyFor(t *testing.T) {
+	policy := Policy{
+		Default: Limit{Rate: 1, Burst: 2},
+		Roles:   map[string]Limit{"member": {Rate: 5, Burst: 10}, "admin": {Rate: 50, Burst: 100}},
+		Users:   map[string]Limit{"bot": {Rate: 10, Burst: 20}},
+		Keys:    map[string]Limit{"key-ci": {Rate: 100, Burst: 200}},
+	}
+	tests := []struct {
+		name      string
+		principal *identity.Principal
+		want      Limit
+		wantKey   string
+	}{
+		{"anonymous", nil, policy.Default, ""},
+		{"no specific limit", &identity.Principal{UserID: "u", WorkspaceID: "w"}, policy.Default, "user:w/u"},
+		{"most generous role", &identity.Principal{UserID: "u", WorkspaceID: "w", Roles: []string{"member", "admin"}}, policy.Roles["admin"], "user:w/u"},
+		{"user over roles", &identity.Principal{UserID: "bot", WorkspaceID: "w", Roles: []string{"admin"}}, policy.Users["bot"], "user:w/bot"},
+		{"key over user", &identity.Principal{UserID: "bot", WorkspaceID: "w", APIKeyID: "key-ci"}, policy.Keys["key-ci"], "apikey:key-ci"},
+		{"key without a limit of its own", &identity.Principal{UserID: "bot", WorkspaceID: "w", APIKeyID: "key-dev"}, policy.Users["bot"], "user:w/bot"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := policy.For(tt.principal); got != tt.want {
+				t.Errorf("For = %v, want %v", got, tt.want)
+			}
+			if tt.principal == nil {
+				return
+			}
+			if got := policy.BucketKey(tt.principal); got != tt.wantKey {
+				t.Errorf("BucketKey = %q, want %q", got, tt.wantKey)
+			}
+		})
+	}
+}
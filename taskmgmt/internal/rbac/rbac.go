@@ -0,0 +1,162 @@
+package rbac
+
+import (
+	"github.com/Cohen-J-Omer/k8-task-mgmt-system/taskmgmt/internal/identity"
+	pb "github.com/Cohen-J-Omer/k8-task-mgmt-system/taskmgmt/proto"
+)
+
+// Permission names an operation a role may be granted.
+type Permission string
+
+const (
+	TaskCreate Permission = "task:create"
+	TaskGet    Permission = "task:get"
+	TaskList   Permission = "task:list"
+	TaskUpdate Permission = "task:update"
+	TaskDelete Permission = "task:delete"
+	// TaskAudit covers listing who can access a task
+	TaskAudit     Permission = "task:audit"
+	TemplateRead  Permission = "template:read"
+	TemplateWrite Permission = "template:write"
+	ProjectRead   Permission = "project:read"
+	// ProjectWrite covers managing projects, their boards and milestones
+	ProjectWrite Permission = "project:write"
+	UserRead     Permission = "user:read"
+	// UserManage covers creating users and teams and managing team members
+	UserManage   Permission = "user:manage"
+	APIKeyManage Permission = "apikey:manage"
+	// WorkspaceManage covers managing the workspaces themselves, and is further limited to operators
+	WorkspaceManage Permission = "workspace:manage"
+	// SystemManage covers administering the running services, such as their log level, and is further limited to operators
+	SystemManage Permission = "system:manage"
+)
+
+// Roles
+const (
+	Viewer = "viewer"
+	Member = "member"
+	Admin  = "admin"
+)
+
+// rolePermissions is the policy granting permissions to roles, admins being granted every permission.
+var rolePermissions = map[string][]Permission{
+	Viewer: {TaskGet, TaskList, TemplateRead, UserRead, ProjectRead},
+	Member: {TaskGet, TaskList, TemplateRead, UserRead, ProjectRead, TaskCreate, TaskUpdate, TaskDelete, TemplateWrite, ProjectWrite},
+}
+
+// ValidRole reports whether role is one of the known roles.
+func ValidRole(role string) bool {
+	return role == Viewer || role == Member || role == Admin
+}
+
+// Allowed reports whether any of the roles grants the permission.
+func Allowed(roles []string, perm Permission) bool {
+	for _, role := range roles {
+		if role == Admin {
+			return true
+		}
+		for _, p := range rolePermissions[role] {
+			if p == perm {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Operator reports whether the principal administers the whole deployment rather than just its
+// workspace: the admins of the default workspace manage workspaces and may act in any of them.
+func Operator(p *identity.Principal) bool {
+	return p.HasRole(Admin) && p.WorkspaceID == identity.DefaultWorkspace
+}
+
+// publicMethods may be called without a principal: the API resolves API keys to principals
+// through AuthenticateAPIKey, limits the rate of anonymous callers too through Take, and Kubernetes
+// probes the health service.
+var publicMethods = map[string]bool{
+	pb.AuthService_AuthenticateAPIKey_FullMethodName: true,
+	pb.RateLimitService_Take_FullMethodName:          true,
+	"/grpc.health.v1.Health/Check":                   true,
+	"/grpc.health.v1.Health/Watch":                   true,
+}
+
+// methodPermissions maps every other backend RPC to the permission it requires.
+// RPCs missing from the map are denied.
+var methodPermissions = map[string]Permission{
+	pb.TaskService_CreateTask_FullMethodName:          TaskCreate,
+	pb.TaskService_GetTask_FullMethodName:             TaskGet,
+	pb.TaskService_GetTasks_FullMethodName:            TaskList,
+	pb.TaskService_UpdateTask_FullMethodName:          TaskUpdate,
+	pb.TaskService_DeleteTask_FullMethodName:          TaskDelete,
+	pb.TaskService_UploadAttachment_FullMethodName:    TaskUpdate,
+	pb.TaskService_DownloadAttachment_FullMethodName:  TaskGet,
+	pb.TaskService_DeleteAttachment_FullMethodName:    TaskUpdate,
+	pb.TaskService_AddChecklistItem_FullMethodName:    TaskUpdate,
+	pb.TaskService_ToggleChecklistItem_FullMethodName: TaskUpdate,
+	pb.TaskService_ReorderChecklist_FullMethodName:    TaskUpdate,
+	pb.TaskService_RemoveChecklistItem_FullMethodName: TaskUpdate,
+	pb.TaskService_ListOccurrences_FullMethodName:     TaskGet,
+	pb.TaskService_StartTimer_FullMethodName:          TaskUpdate,
+	pb.TaskService_StopTimer_FullMethodName:           TaskUpdate,
+	pb.TaskService_AddWorklog_FullMethodName:          TaskUpdate,
+	pb.TaskService_DeleteWorklog_FullMethodName:       TaskUpdate,
+	pb.TaskService_GetTimeReport_FullMethodName:       TaskList,
+	pb.TaskService_AddAssignee_FullMethodName:         TaskUpdate,
+	pb.TaskService_RemoveAssignee_FullMethodName:      TaskUpdate,
+	pb.TaskService_AddWatcher_FullMethodName:          TaskUpdate,
+	pb.TaskService_RemoveWatcher_FullMethodName:       TaskUpdate,
+	pb.TaskService_SetTaskACL_FullMethodName:          TaskUpdate,
+	pb.TaskService_GetTaskAccess_FullMethodName:       TaskAudit,
+
+	pb.TemplateService_CreateTemplate_FullMethodName:      TemplateWrite,
+	pb.TemplateService_GetTemplate_FullMethodName:         TemplateRead,
+	pb.TemplateService_GetTemplates_FullMethodName:        TemplateRead,
+	pb.TemplateService_UpdateTemplate_FullMethodName:      TemplateWrite,
+	pb.TemplateService_DeleteTemplate_FullMethodName:      TemplateWrite,
+	pb.TemplateService_InstantiateTemplate_FullMethodName: TaskCreate,
+
+	pb.ProjectService_CreateProject_FullMethodName: ProjectWrite,
+	pb.ProjectService_GetProject_FullMethodName:    ProjectRead,
+	pb.ProjectService_GetProjects_FullMethodName:   ProjectRead,
+	pb.ProjectService_UpdateProject_FullMethodName: ProjectWrite,
+	pb.ProjectService_DeleteProject_FullMethodName: ProjectWrite,
+	pb.ProjectService_CreateBoard_FullMethodName:   ProjectWrite,
+	pb.ProjectService_GetBoard_FullMethodName:      ProjectRead,
+	pb.ProjectService_GetBoards_FullMethodName:     ProjectRead,
+	pb.ProjectService_UpdateBoard_FullMethodName:   ProjectWrite,
+	pb.ProjectService_DeleteBoard_FullMethodName:   ProjectWrite,
+	// moving a card updates its task
+	pb.ProjectService_MoveCard_FullMethodName: TaskUpdate,
+
+	pb.MilestoneService_CreateMilestone_FullMethodName: ProjectWrite,
+	pb.MilestoneService_GetMilestone_FullMethodName:    ProjectRead,
+	pb.MilestoneService_GetMilestones_FullMethodName:   ProjectRead,
+	pb.MilestoneService_UpdateMilestone_FullMethodName: ProjectWrite,
+	pb.MilestoneService_DeleteMilestone_FullMethodName: ProjectWrite,
+	pb.MilestoneService_GetBurndown_FullMethodName:     ProjectRead,
+
+	pb.UserService_CreateUser_FullMethodName:       UserManage,
+	pb.UserService_GetUser_FullMethodName:          UserRead,
+	pb.UserService_GetUsers_FullMethodName:         UserRead,
+	pb.UserService_UpdateUser_FullMethodName:       UserManage,
+	pb.UserService_CreateTeam_FullMethodName:       UserManage,
+	pb.UserService_GetTeam_FullMethodName:          UserRead,
+	pb.UserService_GetTeams_FullMethodName:         UserRead,
+	pb.UserService_AddTeamMember_FullMethodName:    UserManage,
+	pb.UserService_RemoveTeamMember_FullMethodName: UserManage,
+
+	pb.AuthService_CreateAPIKey_FullMethodName: APIKeyManage,
+	pb.AuthService_GetAPIKeys_FullMethodName:   APIKeyManage,
+	pb.AuthService_RevokeAPIKey_FullMethodName: APIKeyManage,
+	// only the bootstrap admin key acts on behalf of users
+	pb.AuthService_ResolveUser_FullMethodName: UserManage,
+
+	pb.WorkspaceService_CreateWorkspace_FullMethodName: WorkspaceManage,
+	pb.WorkspaceService_GetWorkspace_FullMethodName:    WorkspaceManage,
+	pb.WorkspaceService_GetWorkspaces_FullMethodName:   WorkspaceManage,
+	pb.WorkspaceService_UpdateWorkspace_FullMethodName: WorkspaceManage,
+	pb.WorkspaceService_DeleteWorkspace_FullMethodName: WorkspaceManage,
+
+	pb.AdminService_GetLogLevel_FullMethodName: SystemManage,
+	pb.AdminService_SetLogLevel_FullMethodName: SystemManage,
+}
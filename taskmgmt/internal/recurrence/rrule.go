@@ -0,0 +1,275 @@
+package recurrence
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Frequency is the period a recurrence rule repeats in.
+type Frequency string
+
+const (
+	Daily   Frequency = "DAILY"
+	Weekly  Frequency = "WEEKLY"
+	Monthly Frequency = "MONTHLY"
+)
+
+// maxEmptyPeriods bounds the periods scanned without finding an occurrence,
+// e.g. a monthly rule on the 31st or on the 5th Monday skipping some months.
+const maxEmptyPeriods = 1000
+
+// WeekdayNum is a BYDAY entry, e.g. "MO", or "-1FR" for the last Friday of a month.
+type WeekdayNum struct {
+	Weekday time.Weekday
+	N       int // 0 for every such weekday of the period
+}
+
+// Rule is a parsed iCalendar RRULE, supporting the FREQ (DAILY, WEEKLY, MONTHLY),
+// INTERVAL, BYDAY, BYMONTHDAY, COUNT and UNTIL parts. Weeks start on Monday.
+type Rule struct {
+	Freq     Frequency
+	Interval int
+	ByDay    []WeekdayNum
+	// ByMonthDay lists days of the month, negative ones counting from its end, e.g. -1 for the last day
+	ByMonthDay []int
+	Count      int       // 0 when the rule is not limited by a number of occurrences
+	Until      time.Time // zero when the rule is not limited by an end date
+}
+
+var weekdays = map[string]time.Weekday{
+	"SU": time.Sunday, "MO": time.Monday, "TU": time.Tuesday, "WE": time.Wednesday,
+	"TH": time.Thursday, "FR": time.Friday, "SA": time.Saturday,
+}
+
+// Parse parses an RRULE value such as "FREQ=WEEKLY;BYDAY=MO,WE;COUNT=10".
+// A leading "RRULE:" is accepted.
+func Parse(s string) (*Rule, error) {
+	s = strings.TrimPrefix(strings.TrimSpace(s), "RRULE:")
+	if s == "" {
+		return nil, errors.New("recurrence rule is empty")
+	}
+	r := &Rule{Interval: 1}
+	for _, part := range strings.Split(s, ";") {
+		key, value, ok := strings.Cut(part, "=")
+		if !ok || value == "" {
+			return nil, fmt.Errorf("invalid recurrence rule part %q", part)
+		}
+		switch strings.ToUpper(key) {
+		case "FREQ":
+			switch f := Frequency(strings.ToUpper(value)); f {
+			case Daily, Weekly, Monthly:
+				r.Freq = f
+			default:
+				return nil, fmt.Errorf("unsupported recurrence frequency %q", value)
+			}
+		case "INTERVAL":
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 1 {
+				return nil, fmt.Errorf("invalid recurrence interval %q", value)
+			}
+			r.Interval = n
+		case "COUNT":
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 1 {
+				return nil, fmt.Errorf("invalid recurrence count %q", value)
+			}
+			r.Count = n
+		case "UNTIL":
+			until, err := parseUntil(value)
+			if err != nil {
+				return nil, err
+			}
+			r.Until = until
+		case "BYMONTHDAY":
+			for _, day := range strings.Split(value, ",") {
+				n, err := strconv.Atoi(strings.TrimSpace(day))
+				if err != nil || n == 0 || n < -31 || n > 31 {
+					return nil, fmt.Errorf("invalid BYMONTHDAY entry %q", day)
+				}
+				r.ByMonthDay = append(r.ByMonthDay, n)
+			}
+		case "BYDAY":
+			for _, day := range strings.Split(value, ",") {
+				wd, err := parseWeekdayNum(day)
+				if err != nil {
+					return nil, err
+				}
+				r.ByDay = append(r.ByDay, wd)
+			}
+		default:
+			return nil, fmt.Errorf("unsupported recurrence rule part %q", key)
+		}
+	}
+	if r.Freq == "" {
+		return nil, errors.New("recurrence rule requires FREQ")
+	}
+	if r.Count > 0 && !r.Until.IsZero() {
+		return nil, errors.New("recurrence rule must not set both COUNT and UNTIL")
+	}
+	if len(r.ByMonthDay) > 0 && (r.Freq != Monthly || len(r.ByDay) > 0) {
+		return nil, errors.New("BYMONTHDAY is only supported for MONTHLY rules without BYDAY")
+	}
+	if r.Freq != Monthly {
+		for _, wd := range r.ByDay {
+			if wd.N != 0 {
+				return nil, errors.New("numbered BYDAY entries are only supported for MONTHLY rules")
+			}
+		}
+	}
+	return r, nil
+}
+
+func parseUntil(value string) (time.Time, error) {
+	for _, layout := range []string{"20060102T150405Z", "20060102", time.RFC3339} {
+		if t, err := time.Parse(layout, value); err == nil {
+			if layout == "20060102" {
+				// a date-only UNTIL includes the whole day
+				t = t.Add(24*time.Hour - time.Second)
+			}
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid recurrence until %q", value)
+}
+
+func parseWeekdayNum(s string) (WeekdayNum, error) {
+	s = strings.ToUpper(strings.TrimSpace(s))
+	if len(s) < 2 {
+		return WeekdayNum{}, fmt.Errorf("invalid BYDAY entry %q", s)
+	}
+	wd, ok := weekdays[s[len(s)-2:]]
+	if !ok {
+		return WeekdayNum{}, fmt.Errorf("invalid BYDAY entry %q", s)
+	}
+	n := 0
+	if prefix := s[:len(s)-2]; prefix != "" {
+		var err error
+		n, err = strconv.Atoi(prefix)
+		if err != nil || n == 0 || n < -5 || n > 5 {
+			return WeekdayNum{}, fmt.Errorf("invalid BYDAY entry %q", s)
+		}
+	}
+	return WeekdayNum{Weekday: wd, N: n}, nil
+}
+
+// Iterator yields the occurrences of a rule in chronological order.
+type Iterator struct {
+	rule    *Rule
+	start   time.Time
+	period  int // number of periods since the one holding start
+	pending []time.Time
+	n       int // number of the last returned occurrence
+}
+
+// Iter returns an iterator over the occurrences of a series whose occurrence number n (1-based)
+// falls at start. The first call to Next returns start itself, as DTSTART is always an occurrence.
+func (r *Rule) Iter(start time.Time, n int) *Iterator {
+	return &Iterator{rule: r, start: start, n: n - 1, pending: append([]time.Time{start}, r.candidates(start, 0)...)}
+}
+
+// Next returns the next occurrence and its number, or false once the rule's COUNT or UNTIL is exhausted.
+func (it *Iterator) Next() (time.Time, int, bool) {
+	for empty := 0; len(it.pending) == 0; empty++ {
+		if empty > maxEmptyPeriods {
+			return time.Time{}, 0, false
+		}
+		it.period += it.rule.Interval
+		it.pending = it.rule.candidates(it.start, it.period)
+	}
+	next := it.pending[0]
+	it.pending = it.pending[1:]
+	if it.rule.Count > 0 && it.n+1 > it.rule.Count {
+		return time.Time{}, 0, false
+	}
+	if !it.rule.Until.IsZero() && next.After(it.rule.Until) {
+		return time.Time{}, 0, false
+	}
+	it.n++
+	return next, it.n, true
+}
+
+// candidates returns the sorted occurrences in the period-th period after the one holding start.
+// Occurrences keep start's time of day and location.
+func (r *Rule) candidates(start time.Time, period int) []time.Time {
+	y, m, d := start.Date()
+	at := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, start.Hour(), start.Minute(), start.Second(), 0, start.Location())
+	}
+	var out []time.Time
+	switch r.Freq {
+	case Daily:
+		day := at(y, m, d+period)
+		if len(r.ByDay) == 0 || r.matchesWeekday(day.Weekday()) {
+			out = append(out, day)
+		}
+	case Weekly:
+		// days since the Monday starting start's week
+		offset := (int(start.Weekday()) + 6) % 7
+		monday := d - offset + 7*period
+		if len(r.ByDay) == 0 {
+			out = append(out, at(y, m, monday+offset))
+		}
+		for _, wd := range r.ByDay {
+			out = append(out, at(y, m, monday+(int(wd.Weekday)+6)%7))
+		}
+	case Monthly:
+		first := time.Date(y, m+time.Month(period), 1, 0, 0, 0, 0, start.Location())
+		year, month := first.Year(), first.Month()
+		days := time.Date(year, month+1, 0, 0, 0, 0, 0, start.Location()).Day()
+		if len(r.ByDay) == 0 && len(r.ByMonthDay) == 0 {
+			// months without start's day of month are skipped
+			if d <= days {
+				out = append(out, at(year, month, d))
+			}
+		}
+		for _, day := range r.ByMonthDay {
+			if day < 0 {
+				day += days + 1
+			}
+			// months without the listed day are skipped too
+			if day >= 1 && day <= days {
+				out = append(out, at(year, month, day))
+			}
+		}
+		for _, wd := range r.ByDay {
+			firstMatch := 1 + (int(wd.Weekday)-int(first.Weekday())+7)%7
+			var matches []int
+			for day := firstMatch; day <= days; day += 7 {
+				matches = append(matches, day)
+			}
+			switch {
+			case wd.N == 0:
+				for _, day := range matches {
+					out = append(out, at(year, month, day))
+				}
+			case wd.N > 0 && wd.N <= len(matches):
+				out = append(out, at(year, month, matches[wd.N-1]))
+			case wd.N < 0 && -wd.N <= len(matches):
+				out = append(out, at(year, month, matches[len(matches)+wd.N]))
+			}
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Before(out[j]) })
+	// drop duplicates and, in start's own period, the candidates not after start
+	uniq := out[:0]
+	for _, t := range out {
+		if (period == 0 && !t.After(start)) || (len(uniq) > 0 && uniq[len(uniq)-1].Equal(t)) {
+			continue
+		}
+		uniq = append(uniq, t)
+	}
+	return uniq
+}
+
+func (r *Rule) matchesWeekday(wd time.Weekday) bool {
+	for _, day := range r.ByDay {
+		if day.Weekday == wd {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,183 @@
+package recurrence
+
+import (
+	"testing"
+	"time"
+)
+
+// dates returns up to max occurrences of the rule from start, the n-th occurrence of its series, as
+// dates and times.
+func dates(t *testing.T, rule string, start string, n, max int) []string {
+	t.Helper()
+	r, err := Parse(rule)
+	if err != nil {
+		t.Fatalf("Parse(%q): %v", rule, err)
+	}
+	dtstart, err := time.Parse(time.RFC3339, start)
+	if err != nil {
+		t.Fatalf("invalid start %q: %v", start, err)
+	}
+	var out []string
+	it := r.Iter(dtstart, n)
+	for len(out) < max {
+		next, num, ok := it.Next()
+		if !ok {
+			break
+		}
+		if num != n+len(out) {
+			t.Fatalf("occurrence %s is numbered %d, want %d", next, num, n+len(out))
+		}
+		out = append(out, next.Format("2006-01-02T15:04"))
+	}
+	return out
+}
+
+func TestIter(t *testing.T) {
+	tests := []struct {
+		name  string
+		rule  string
+		start string
+		n     int
+		want  []string
+		// bounded rules are checked to end after the wanted occurrences, others only compared over them
+		bounded bool
+	}{
+		{
+			name:    "daily with interval and count",
+			rule:    "FREQ=DAILY;INTERVAL=2;COUNT=4",
+			start:   "2026-01-01T09:00:00Z",
+			want:    []string{"2026-01-01T09:00", "2026-01-03T09:00", "2026-01-05T09:00", "2026-01-07T09:00"},
+			bounded: true,
+		},
+		{
+			name:  "daily on weekdays",
+			rule:  "FREQ=DAILY;BYDAY=MO,TU,WE,TH,FR",
+			start: "2026-10-22T09:00:00Z",
+			want:  []string{"2026-10-22T09:00", "2026-10-23T09:00", "2026-10-26T09:00", "2026-10-27T09:00"},
+		},
+		{
+			name:  "weekly on several days",
+			rule:  "FREQ=WEEKLY;BYDAY=MO,WE",
+			start: "2026-10-19T09:00:00Z",
+			want:  []string{"2026-10-19T09:00", "2026-10-21T09:00", "2026-10-26T09:00", "2026-10-28T09:00", "2026-11-02T09:00"},
+		},
+		{
+			name:  "every other week",
+			rule:  "RRULE:FREQ=WEEKLY;INTERVAL=2",
+			start: "2026-10-19T09:00:00Z",
+			want:  []string{"2026-10-19T09:00", "2026-11-02T09:00", "2026-11-16T09:00"},
+		},
+		{
+			name:  "start off the rule's days is still an occurrence",
+			rule:  "FREQ=WEEKLY;BYDAY=FR",
+			start: "2026-10-19T09:00:00Z",
+			want:  []string{"2026-10-19T09:00", "2026-10-23T09:00", "2026-10-30T09:00"},
+		},
+		{
+			name:  "monthly on the last Friday",
+			rule:  "FREQ=MONTHLY;BYDAY=-1FR",
+			start: "2026-01-30T17:00:00Z",
+			want:  []string{"2026-01-30T17:00", "2026-02-27T17:00", "2026-03-27T17:00", "2026-04-24T17:00"},
+		},
+		{
+			name:  "monthly on the first Monday",
+			rule:  "freq=monthly;byday=1mo",
+			start: "2026-01-05T09:00:00Z",
+			want:  []string{"2026-01-05T09:00", "2026-02-02T09:00", "2026-03-02T09:00"},
+		},
+		{
+			name:  "monthly on the 5th Monday skips months without one",
+			rule:  "FREQ=MONTHLY;BYDAY=5MO",
+			start: "2026-03-30T09:00:00Z",
+			want:  []string{"2026-03-30T09:00", "2026-06-29T09:00", "2026-08-31T09:00"},
+		},
+		{
+			name:  "monthly on the 31st skips short months",
+			rule:  "FREQ=MONTHLY;BYMONTHDAY=31",
+			start: "2026-01-31T09:00:00Z",
+			want:  []string{"2026-01-31T09:00", "2026-03-31T09:00", "2026-05-31T09:00", "2026-07-31T09:00"},
+		},
+		{
+			name:  "monthly on the last day",
+			rule:  "FREQ=MONTHLY;BYMONTHDAY=-1",
+			start: "2026-01-31T09:00:00Z",
+			want:  []string{"2026-01-31T09:00", "2026-02-28T09:00", "2026-03-31T09:00", "2026-04-30T09:00"},
+		},
+		{
+			name:  "monthly on start's day skips short months",
+			rule:  "FREQ=MONTHLY",
+			start: "2026-01-31T09:00:00Z",
+			want:  []string{"2026-01-31T09:00", "2026-03-31T09:00", "2026-05-31T09:00"},
+		},
+		{
+			name:    "until a date includes that day",
+			rule:    "FREQ=WEEKLY;BYDAY=MO;UNTIL=20261102",
+			start:   "2026-10-19T09:00:00Z",
+			want:    []string{"2026-10-19T09:00", "2026-10-26T09:00", "2026-11-02T09:00"},
+			bounded: true,
+		},
+		{
+			name:    "until a time",
+			rule:    "FREQ=WEEKLY;BYDAY=MO;UNTIL=20261102T000000Z",
+			start:   "2026-10-19T09:00:00Z",
+			want:    []string{"2026-10-19T09:00", "2026-10-26T09:00"},
+			bounded: true,
+		},
+		{
+			name:    "count carries on from a later occurrence",
+			rule:    "FREQ=DAILY;COUNT=4",
+			start:   "2026-01-03T09:00:00Z",
+			n:       3,
+			want:    []string{"2026-01-03T09:00", "2026-01-04T09:00"},
+			bounded: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			n := tt.n
+			if n == 0 {
+				n = 1
+			}
+			max := len(tt.want)
+			if tt.bounded {
+				max++
+			}
+			got := dates(t, tt.rule, tt.start, n, max)
+			if len(got) != len(tt.want) {
+				t.Fatalf("got occurrences %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Fatalf("got occurrences %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestParseRejectsInvalidRules(t *testing.T) {
+	for _, rule := range []string{
+		"",
+		"FREQ",
+		"INTERVAL=2",
+		"FREQ=YEARLY",
+		"FREQ=DAILY;INTERVAL=0",
+		"FREQ=DAILY;COUNT=-1",
+		"FREQ=DAILY;COUNT=2;UNTIL=20260101",
+		"FREQ=DAILY;UNTIL=tomorrow",
+		"FREQ=DAILY;BYHOUR=9",
+		"FREQ=WEEKLY;BYDAY=1MO",
+		"FREQ=MONTHLY;BYDAY=6MO",
+		"FREQ=MONTHLY;BYDAY=0MO",
+		"FREQ=MONTHLY;BYDAY=XX",
+		"FREQ=MONTHLY;BYMONTHDAY=0",
+		"FREQ=MONTHLY;BYMONTHDAY=32",
+		"FREQ=MONTHLY;BYMONTHDAY=-32",
+		"FREQ=WEEKLY;BYMONTHDAY=1",
+		"FREQ=MONTHLY;BYDAY=MO;BYMONTHDAY=1",
+	} {
+		if _, err := Parse(rule); err == nil {
+			t.Errorf("Parse(%q) accepted an invalid rule", rule)
+		}
+	}
+}
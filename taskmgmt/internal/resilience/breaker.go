@@ -0,0 +1,136 @@
+package resilience
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/Cohen-J-Omer/k8-task-mgmt-system/taskmgmt/internal/metrics"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type breakerState int
+
+// the values are those of metrics.CircuitBreakerState
+const (
+	closed breakerState = iota
+	halfOpen
+	open
+)
+
+// Breaker is a circuit breaker on the backend. After Threshold consecutive failed attempts it opens,
+// failing calls fast for Cooldown, then lets a single probe through: the breaker closes if the probe
+// succeeds and opens again otherwise. Only failures suggesting the backend is down or unresponsive
+// count, that is Unavailable and DeadlineExceeded. A call rejected by the backend, e.g. with NotFound
+// or with ResourceExhausted when it sheds load, shows it's up, and a call exceeding a deadline the
+// client shortened tells nothing about it.
+type Breaker struct {
+	Threshold int
+	Cooldown  time.Duration
+
+	mu         sync.Mutex
+	state      breakerState
+	generation uint64    // incremented on each state change
+	failures   int       // consecutive failures while closed
+	openedAt   time.Time // when the breaker last opened
+	probing    bool      // whether the half-open breaker's probe is in flight
+}
+
+// Ticket identifies an attempt let through by Allow, whose outcome is recorded with it.
+type Ticket struct {
+	generation uint64
+	probe      bool
+}
+
+// NewBreaker returns a closed circuit breaker.
+func NewBreaker(threshold int, cooldown time.Duration) *Breaker {
+	metrics.CircuitBreakerState.Set(float64(closed))
+	return &Breaker{Threshold: threshold, Cooldown: cooldown}
+}
+
+// Allow reports whether an attempt may be made now, returning its ticket, and otherwise how long
+// until the breaker lets a probe through.
+func (b *Breaker) Allow() (Ticket, bool, time.Duration) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	switch b.state {
+	case open:
+		if wait := b.Cooldown - time.Since(b.openedAt); wait > 0 {
+			return Ticket{}, false, wait
+		}
+		b.setState(halfOpen)
+		b.probing = true
+		return Ticket{generation: b.generation, probe: true}, true, 0
+	case halfOpen:
+		if b.probing {
+			return Ticket{}, false, time.Second
+		}
+		b.probing = true
+		return Ticket{generation: b.generation, probe: true}, true, 0
+	default:
+		return Ticket{generation: b.generation}, true, 0
+	}
+}
+
+// Record records the outcome of the attempt of the ticket, made with ctx. Outcomes of attempts allowed
+// before the breaker last changed state are ignored: a call let through while it was closed may end
+// after it opened, and must neither cut the cooldown short nor decide the probe's verdict.
+func (b *Breaker) Record(ctx context.Context, t Ticket, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if t.generation != b.generation {
+		return
+	}
+	code := status.Code(err)
+	if code == codes.Canceled || (code == codes.DeadlineExceeded && ClientDeadline(ctx)) {
+		// the caller gave up, or didn't give the backend the time it's budgeted, which tells nothing
+		// about it; another probe may be let through
+		if t.probe {
+			b.probing = false
+		}
+		return
+	}
+	failed := code == codes.Unavailable || code == codes.DeadlineExceeded
+	switch {
+	case b.state == halfOpen:
+		// only the probe is let through while half-open
+		b.probing = false
+		if failed {
+			b.openedAt = time.Now()
+			b.setState(open)
+		} else {
+			b.failures = 0
+			b.setState(closed)
+		}
+	case !failed:
+		b.failures = 0
+	default:
+		b.failures++
+		if b.failures >= b.Threshold {
+			b.failures = 0
+			b.openedAt = time.Now()
+			b.setState(open)
+		}
+	}
+}
+
+type clientDeadlineKey struct{}
+
+// WithClientDeadline marks the deadline of ctx as set shorter than the server's budget by the client,
+// so that calls exceeding it don't count as the backend's failures.
+func WithClientDeadline(ctx context.Context) context.Context {
+	return context.WithValue(ctx, clientDeadlineKey{}, true)
+}
+
+// ClientDeadline reports whether the deadline of ctx was set shorter by the client.
+func ClientDeadline(ctx context.Context) bool {
+	v, _ := ctx.Value(clientDeadlineKey{}).(bool)
+	return v
+}
+
+func (b *Breaker) setState(state breakerState) {
+	b.state = state
+	b.generation++
+	metrics.CircuitBreakerState.Set(float64(state))
+}
@@ -0,0 +1,173 @@
+package resilience
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	errUnavailable = status.Error(codes.Unavailable, "backend is down")
+	errDeadline    = status.Error(codes.DeadlineExceeded, "deadline exceeded")
+	errNotFound    = status.Error(codes.NotFound, "task not found")
+)
+
+// fail records n allowed attempts failing with err.
+func fail(t *testing.T, b *Breaker, ctx context.Context, n int, err error) {
+	t.Helper()
+	for i := 0; i < n; i++ {
+		ticket, ok, _ := b.Allow()
+		if !ok {
+			t.Fatalf("attempt %d was rejected", i+1)
+		}
+		b.Record(ctx, ticket, err)
+	}
+}
+
+// expire makes the open breaker's cooldown elapse.
+func expire(b *Breaker) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.openedAt = time.Now().Add(-b.Cooldown)
+}
+
+func TestBreakerOpensAfterThreshold(t *testing.T) {
+	b := NewBreaker(3, time.Minute)
+	ctx := context.Background()
+
+	fail(t, b, ctx, 2, errUnavailable)
+	if b.state != closed {
+		t.Fatalf("breaker opened after 2 of 3 failures")
+	}
+	fail(t, b, ctx, 1, errDeadline)
+	if b.state != open {
+		t.Fatalf("breaker didn't open after 3 failures")
+	}
+	_, ok, wait := b.Allow()
+	if ok || wait <= 0 || wait > time.Minute {
+		t.Errorf("open breaker returned (%v, %v), want a rejection with the cooldown left", ok, wait)
+	}
+}
+
+func TestBreakerCountsConsecutiveFailuresOnly(t *testing.T) {
+	b := NewBreaker(3, time.Minute)
+	ctx := context.Background()
+
+	fail(t, b, ctx, 2, errUnavailable)
+	// a rejection by the backend shows it's up
+	fail(t, b, ctx, 1, errNotFound)
+	fail(t, b, ctx, 2, errUnavailable)
+	if b.state != closed {
+		t.Errorf("breaker opened although failures weren't consecutive")
+	}
+}
+
+func TestBreakerHalfOpenProbe(t *testing.T) {
+	tests := []struct {
+		name      string
+		probeErr  error
+		wantState breakerState
+	}{
+		{"probe succeeds", nil, closed},
+		{"probe rejected by the backend", errNotFound, closed},
+		{"probe fails", errUnavailable, open},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := NewBreaker(1, time.Minute)
+			ctx := context.Background()
+			fail(t, b, ctx, 1, errUnavailable)
+			expire(b)
+
+			probe, ok, _ := b.Allow()
+			if !ok {
+				t.Fatal("breaker didn't let a probe through after its cooldown")
+			}
+			if b.state != halfOpen {
+				t.Fatalf("breaker is in state %d while probing, want half-open", b.state)
+			}
+			// only a single probe is in flight
+			if _, ok, _ := b.Allow(); ok {
+				t.Fatal("breaker let a second probe through")
+			}
+			b.Record(ctx, probe, tt.probeErr)
+			if b.state != tt.wantState {
+				t.Errorf("breaker is in state %d after the probe, want %d", b.state, tt.wantState)
+			}
+		})
+	}
+}
+
+func TestBreakerIgnoresCallerFailures(t *testing.T) {
+	tests := []struct {
+		name string
+		ctx  context.Context
+		err  error
+	}{
+		{"canceled", context.Background(), status.Error(codes.Canceled, "canceled")},
+		{"deadline shortened by the client", WithClientDeadline(context.Background()), errDeadline},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := NewBreaker(1, time.Minute)
+			fail(t, b, tt.ctx, 3, tt.err)
+			if b.state != closed {
+				t.Errorf("breaker opened on failures caused by the caller")
+			}
+
+			// nor do they end a probe's verdict, though they free its slot
+			fail(t, b, context.Background(), 1, errUnavailable)
+			expire(b)
+			fail(t, b, tt.ctx, 1, tt.err)
+			if b.state != halfOpen {
+				t.Errorf("breaker is in state %d after an inconclusive probe, want half-open", b.state)
+			}
+			if _, ok, _ := b.Allow(); !ok {
+				t.Errorf("breaker didn't let another probe through after an inconclusive one")
+			}
+		})
+	}
+}
+
+func TestBreakerCountsServerDeadlines(t *testing.T) {
+	b := NewBreaker(2, time.Minute)
+	// the server's own budget running out suggests it's overloaded
+	fail(t, b, context.Background(), 2, errDeadline)
+	if b.state != open {
+		t.Errorf("breaker didn't open on calls exceeding the server's budget")
+	}
+}
+
+func TestBreakerIgnoresResultsOfEarlierStates(t *testing.T) {
+	b := NewBreaker(1, time.Minute)
+	ctx := context.Background()
+
+	// a call let through while closed succeeds only after others opened the breaker
+	early, _, _ := b.Allow()
+	fail(t, b, ctx, 1, errUnavailable)
+	b.Record(ctx, early, nil)
+	if b.state != open {
+		t.Fatalf("a success allowed before the breaker opened cut its cooldown short")
+	}
+
+	// or fails while the probe is in flight
+	expire(b)
+	probe, ok, _ := b.Allow()
+	if !ok {
+		t.Fatal("breaker didn't let a probe through after its cooldown")
+	}
+	b.Record(ctx, early, errUnavailable)
+	if b.state != halfOpen {
+		t.Fatalf("a result allowed before the probe decided its verdict")
+	}
+	if _, ok, _ := b.Allow(); ok {
+		t.Fatal("a result allowed before the probe let a second probe through")
+	}
+	b.Record(ctx, probe, nil)
+	if b.state != closed {
+		t.Errorf("breaker is in state %d after the probe succeeded, want closed", b.state)
+	}
+}
@@ -0,0 +1,243 @@
+// Package resilience makes the API's backend calls resilient to transient failures: idempotent calls
+// are retried with exponential backoff and jitter, or hedged, as configured per method, and a circuit
+// breaker fails calls fast while the backend is down, telling clients when to retry.
+package resilience
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"time"
+
+	"github.com/Cohen-J-Omer/k8-task-mgmt-system/taskmgmt/internal/metrics"
+	pb "github.com/Cohen-J-Omer/k8-task-mgmt-system/taskmgmt/proto"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// Policy configures the attempts at calls to a method.
+type Policy struct {
+	// MaxAttempts bounds the attempts at a call, the first one included.
+	MaxAttempts int `json:"max_attempts"`
+	// InitialBackoffMS and MaxBackoffMS bound the delay before the first retry and any retry, in
+	// milliseconds. The bound grows by BackoffMultiplier after each retry, and the actual delay is
+	// drawn uniformly below it, so clients retrying together don't hit the backend in lockstep.
+	InitialBackoffMS  int64   `json:"initial_backoff_ms"`
+	MaxBackoffMS      int64   `json:"max_backoff_ms"`
+	BackoffMultiplier float64 `json:"backoff_multiplier"`
+	// HedgeDelayMS, if set, hedges calls instead of retrying them: while no attempt has succeeded, a new
+	// one is started every HedgeDelayMS milliseconds, or at once when one fails with a retryable code,
+	// and the first to succeed is used. Only hedge cheap reads, as they multiply the backend's load.
+	HedgeDelayMS int64 `json:"hedge_delay_ms"`
+	// RetryableCodes are the status codes of failed attempts worth another one, e.g. "UNAVAILABLE".
+	RetryableCodes []codes.Code `json:"retryable_codes"`
+}
+
+// Config maps methods to their policies. Keys are full method names, e.g. /task.TaskService/GetTask,
+// or service names, e.g. task.TaskService, for all of a service's methods. Calls to other methods are
+// attempted once.
+type Config map[string]Policy
+
+// readPolicy retries reads, which are idempotent, when the backend is unreachable. Calls the backend
+// shed as overloaded aren't retried, as retrying them sooner than it asks would add to its load.
+var readPolicy = Policy{
+	MaxAttempts:       3,
+	InitialBackoffMS:  50,
+	MaxBackoffMS:      500,
+	BackoffMultiplier: 2,
+	RetryableCodes:    []codes.Code{codes.Unavailable},
+}
+
+// DefaultConfig retries task reads. Writes aren't retried: without idempotency keys, a retried write
+// whose first attempt reached the backend would be applied twice.
+var DefaultConfig = Config{
+	pb.TaskService_GetTask_FullMethodName:  readPolicy,
+	pb.TaskService_GetTasks_FullMethodName: readPolicy,
+}
+
+// ParseConfig parses a JSON object mapping methods or services to policies, returning DefaultConfig
+// for an empty string.
+func ParseConfig(s string) (Config, error) {
+	if s == "" {
+		return DefaultConfig, nil
+	}
+	var cfg Config
+	if err := json.Unmarshal([]byte(s), &cfg); err != nil {
+		return nil, fmt.Errorf("invalid retry policies: %w", err)
+	}
+	for name, p := range cfg {
+		if p.MaxAttempts < 1 || p.InitialBackoffMS < 0 || p.MaxBackoffMS < p.InitialBackoffMS || p.HedgeDelayMS < 0 {
+			return nil, fmt.Errorf("invalid retry policy of %s: max_attempts must be positive and backoffs ordered", name)
+		}
+		if p.BackoffMultiplier < 1 {
+			p.BackoffMultiplier = 1
+			cfg[name] = p
+		}
+	}
+	return cfg, nil
+}
+
+// policy returns the policy of the full method, a single attempt if none is configured.
+func (c Config) policy(fullMethod string) Policy {
+	if p, ok := c[fullMethod]; ok {
+		return p
+	}
+	service, _ := metrics.SplitMethod(fullMethod)
+	if p, ok := c[service]; ok {
+		return p
+	}
+	return Policy{MaxAttempts: 1}
+}
+
+func (p Policy) retryable(err error) bool {
+	return slices.Contains(p.RetryableCodes, status.Code(err))
+}
+
+// backoff returns the delay before the retry following the given number of attempts.
+func (p Policy) backoff(attempts int) time.Duration {
+	bound := float64(p.InitialBackoffMS)
+	for i := 1; i < attempts; i++ {
+		bound *= p.BackoffMultiplier
+	}
+	bound = min(bound, float64(p.MaxBackoffMS))
+	if bound <= 0 {
+		return 0
+	}
+	return time.Duration(rand.Int64N(int64(bound*float64(time.Millisecond)) + 1))
+}
+
+// CircuitOpenError is the Unavailable error of calls failed fast by the open circuit breaker, telling
+// in a RetryInfo detail how long until the backend is tried again.
+func CircuitOpenError(retryAfter time.Duration) error {
+	st := status.New(codes.Unavailable, "backend unavailable, circuit breaker open")
+	if detailed, err := st.WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(retryAfter)}); err == nil {
+		st = detailed
+	}
+	return st.Err()
+}
+
+// RetryAfter returns the delay after which the call failing with err may be retried, if told.
+func RetryAfter(err error) (time.Duration, bool) {
+	for _, d := range status.Convert(err).Details() {
+		if info, ok := d.(*errdetails.RetryInfo); ok {
+			return info.RetryDelay.AsDuration(), true
+		}
+	}
+	return 0, false
+}
+
+// UnaryClientInterceptor applies the configured policies and the circuit breaker to unary calls.
+// Chained first, each attempt goes through the later interceptors, e.g. to be signed anew.
+func UnaryClientInterceptor(cfg Config, breaker *Breaker) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		service, name := metrics.SplitMethod(method)
+		// attempt makes an attempt unless the breaker is open, reporting whether it was rejected
+		attempt := func(ctx context.Context, reply interface{}) (rejected bool, err error) {
+			ticket, ok, wait := breaker.Allow()
+			if !ok {
+				metrics.CircuitBreakerRejections.WithLabelValues(service, name).Inc()
+				return true, CircuitOpenError(wait)
+			}
+			err = invoker(ctx, method, req, reply, cc, opts...)
+			breaker.Record(ctx, ticket, err)
+			return false, err
+		}
+		policy := cfg.policy(method)
+		if policy.HedgeDelayMS > 0 && policy.MaxAttempts > 1 {
+			if msg, ok := reply.(proto.Message); ok {
+				return hedge(ctx, policy, msg, attempt, func() {
+					metrics.GRPCClientRetries.WithLabelValues(service, name, "hedge").Inc()
+				})
+			}
+		}
+		for attempts := 1; ; attempts++ {
+			rejected, err := attempt(ctx, reply)
+			if err == nil || rejected || attempts >= policy.MaxAttempts || !policy.retryable(err) {
+				return err
+			}
+			select {
+			case <-time.After(policy.backoff(attempts)):
+			case <-ctx.Done():
+				return err
+			}
+			metrics.GRPCClientRetries.WithLabelValues(service, name, "retry").Inc()
+		}
+	}
+}
+
+// hedge runs the attempts at a call hedged, each with its own reply, copying the first successful
+// reply into reply. The attempts still running then are canceled.
+func hedge(ctx context.Context, policy Policy, reply proto.Message, attempt func(context.Context, interface{}) (bool, error), hedged func()) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	type result struct {
+		reply    proto.Message
+		err      error
+		rejected bool
+	}
+	results := make(chan result, policy.MaxAttempts)
+	started, running := 0, 0
+	start := func() {
+		if started > 0 {
+			hedged()
+		}
+		started++
+		running++
+		r := reply.ProtoReflect().New().Interface()
+		go func() {
+			rejected, err := attempt(ctx, r)
+			results <- result{r, err, rejected}
+		}()
+	}
+	delay := time.Duration(policy.HedgeDelayMS) * time.Millisecond
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	start()
+	var err error
+	for running > 0 {
+		select {
+		case <-timer.C:
+			if started < policy.MaxAttempts {
+				start()
+				timer.Reset(delay)
+			}
+		case r := <-results:
+			running--
+			if r.err == nil {
+				proto.Merge(reply, r.reply)
+				return nil
+			}
+			err = r.err
+			if r.rejected || !policy.retryable(r.err) {
+				return r.err
+			}
+			if running == 0 && started < policy.MaxAttempts && ctx.Err() == nil {
+				start()
+				timer.Reset(delay)
+			}
+		}
+	}
+	return err
+}
+
+// StreamClientInterceptor fails streaming calls fast while the circuit breaker is open. Streams aren't
+// retried, as messages may already have been exchanged when they fail.
+func StreamClientInterceptor(breaker *Breaker) grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		ticket, ok, wait := breaker.Allow()
+		if !ok {
+			service, name := metrics.SplitMethod(method)
+			metrics.CircuitBreakerRejections.WithLabelValues(service, name).Inc()
+			return nil, CircuitOpenError(wait)
+		}
+		stream, err := streamer(ctx, desc, cc, method, opts...)
+		breaker.Record(ctx, ticket, err)
+		return stream, err
+	}
+}
@@ -0,0 +1,225 @@
+package svcauth
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/Cohen-J-Omer/k8-task-mgmt-system/taskmgmt/internal/identity"
+	pb "github.com/Cohen-J-Omer/k8-task-mgmt-system/taskmgmt/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	testSecret = "shared-secret"
+	testMethod = "/task.TaskService/GetTask"
+)
+
+// signedCall returns the metadata the API's interceptors send with a call of the principal to method.
+func signedCall(t *testing.T, secret, method string, req proto.Message) metadata.MD {
+	t.Helper()
+	ctx := identity.NewOutgoingContext(context.Background(), &identity.Principal{
+		UserID: "alice", WorkspaceID: "acme", TeamIDs: []string{"platform"}, Roles: []string{"member"},
+	})
+	var sent metadata.MD
+	invoker := func(ctx context.Context, _ string, _, _ interface{}, _ *grpc.ClientConn, _ ...grpc.CallOption) error {
+		sent, _ = metadata.FromOutgoingContext(ctx)
+		return nil
+	}
+	if err := UnaryClientInterceptor(secret)(ctx, method, req, nil, nil, invoker); err != nil {
+		t.Fatalf("signing call: %v", err)
+	}
+	return sent
+}
+
+// receive runs the backend's interceptor on a call with the metadata, returning its error.
+func receive(md metadata.MD, method string, req proto.Message) error {
+	ctx := metadata.NewIncomingContext(context.Background(), md)
+	handler := func(context.Context, interface{}) (interface{}, error) { return nil, nil }
+	_, err := UnaryServerInterceptor(testSecret)(ctx, req, &grpc.UnaryServerInfo{FullMethod: method}, handler)
+	return err
+}
+
+// resign signs md again as of the time, as a holder of the secret would.
+func resign(md metadata.MD, method string, req proto.Message, at time.Time) {
+	d, _ := digest(req)
+	timestamp := strconv.FormatInt(at.Unix(), 10)
+	md.Set(TimestampMetadataKey, timestamp)
+	md.Set(SignatureMetadataKey, sign([]byte(testSecret), method, timestamp, d, md))
+}
+
+func TestUnarySignature(t *testing.T) {
+	req := &pb.TaskID{Id: "t1"}
+	tests := []struct {
+		name   string
+		secret string
+		tamper func(md metadata.MD) (method string, req proto.Message)
+		ok     bool
+	}{
+		{
+			name: "valid call",
+			ok:   true,
+		},
+		{
+			name: "tampered user",
+			tamper: func(md metadata.MD) (string, proto.Message) {
+				md.Set(identity.UserIDMetadataKey, "mallory")
+				return testMethod, req
+			},
+		},
+		{
+			name: "tampered workspace",
+			tamper: func(md metadata.MD) (string, proto.Message) {
+				md.Set(identity.WorkspaceMetadataKey, "other")
+				return testMethod, req
+			},
+		},
+		{
+			name: "added role",
+			tamper: func(md metadata.MD) (string, proto.Message) {
+				md.Append(identity.RoleMetadataKey, "admin")
+				return testMethod, req
+			},
+		},
+		{
+			name: "different method",
+			tamper: func(md metadata.MD) (string, proto.Message) {
+				return "/task.TaskService/DeleteTask", req
+			},
+		},
+		{
+			name: "request not matching its digest",
+			tamper: func(md metadata.MD) (string, proto.Message) {
+				return testMethod, &pb.TaskID{Id: "t2"}
+			},
+		},
+		{
+			name: "signed too long ago",
+			tamper: func(md metadata.MD) (string, proto.Message) {
+				resign(md, testMethod, req, time.Now().Add(-MaxSkew-time.Minute))
+				return testMethod, req
+			},
+		},
+		{
+			name: "signed too far in the future",
+			tamper: func(md metadata.MD) (string, proto.Message) {
+				resign(md, testMethod, req, time.Now().Add(MaxSkew+time.Minute))
+				return testMethod, req
+			},
+		},
+		{
+			name: "signed just within the skew",
+			tamper: func(md metadata.MD) (string, proto.Message) {
+				resign(md, testMethod, req, time.Now().Add(-MaxSkew+time.Minute))
+				return testMethod, req
+			},
+			ok: true,
+		},
+		{
+			name:   "wrong key",
+			secret: "other-secret",
+		},
+		{
+			name: "missing signature",
+			tamper: func(md metadata.MD) (string, proto.Message) {
+				md.Delete(SignatureMetadataKey)
+				return testMethod, req
+			},
+		},
+		{
+			name: "missing timestamp",
+			tamper: func(md metadata.MD) (string, proto.Message) {
+				md.Delete(TimestampMetadataKey)
+				return testMethod, req
+			},
+		},
+		{
+			name: "repeated signature",
+			tamper: func(md metadata.MD) (string, proto.Message) {
+				md.Append(SignatureMetadataKey, md.Get(SignatureMetadataKey)[0])
+				return testMethod, req
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			secret := tt.secret
+			if secret == "" {
+				secret = testSecret
+			}
+			md := signedCall(t, secret, testMethod, req)
+			method, received := testMethod, proto.Message(req)
+			if tt.tamper != nil {
+				method, received = tt.tamper(md)
+			}
+			err := receive(md, method, received)
+			if tt.ok && err != nil {
+				t.Errorf("call was rejected: %v", err)
+			}
+			if !tt.ok && status.Code(err) != codes.Unauthenticated {
+				t.Errorf("call returned %v, want Unauthenticated", err)
+			}
+		})
+	}
+}
+
+func TestPublicMethodsAreUnsigned(t *testing.T) {
+	if err := receive(metadata.MD{}, "/grpc.health.v1.Health/Check", &pb.TaskID{}); err != nil {
+		t.Errorf("health check was rejected: %v", err)
+	}
+	if err := receive(metadata.MD{}, testMethod, &pb.TaskID{Id: "t1"}); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("unsigned call returned %v, want Unauthenticated", err)
+	}
+}
+
+func TestStreamSignature(t *testing.T) {
+	ctx := identity.NewOutgoingContext(context.Background(), &identity.Principal{UserID: "alice", WorkspaceID: "acme"})
+	var sent metadata.MD
+	streamer := func(ctx context.Context, _ *grpc.StreamDesc, _ *grpc.ClientConn, _ string, _ ...grpc.CallOption) (grpc.ClientStream, error) {
+		sent, _ = metadata.FromOutgoingContext(ctx)
+		return nil, nil
+	}
+	const method = "/task.TaskService/UploadAttachment"
+	if _, err := StreamClientInterceptor(testSecret)(ctx, nil, nil, method, streamer); err != nil {
+		t.Fatalf("signing stream: %v", err)
+	}
+
+	for name, tt := range map[string]struct {
+		method string
+		user   string
+		ok     bool
+	}{
+		"valid stream":     {method, "alice", true},
+		"different method": {"/task.TaskService/DownloadAttachment", "alice", false},
+		"tampered user":    {method, "mallory", false},
+	} {
+		t.Run(name, func(t *testing.T) {
+			md := sent.Copy()
+			md.Set(identity.UserIDMetadataKey, tt.user)
+			stream := &serverStream{ctx: metadata.NewIncomingContext(context.Background(), md)}
+			handler := func(interface{}, grpc.ServerStream) error { return nil }
+			err := StreamServerInterceptor(testSecret)(nil, stream, &grpc.StreamServerInfo{FullMethod: tt.method}, handler)
+			if tt.ok && err != nil {
+				t.Errorf("stream was rejected: %v", err)
+			}
+			if !tt.ok && status.Code(err) != codes.Unauthenticated {
+				t.Errorf("stream returned %v, want Unauthenticated", err)
+			}
+		})
+	}
+}
+
+// serverStream is a grpc.ServerStream only providing its context.
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
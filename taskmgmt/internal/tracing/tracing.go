@@ -0,0 +1,106 @@
+// Package tracing sets up OpenTelemetry tracing. Spans are propagated in W3C traceparent headers and
+// gRPC metadata, so a request is traced from the API through the backend down to its MongoDB commands.
+package tracing
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Cohen-J-Omer/k8-task-mgmt-system/taskmgmt/internal/config"
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/event"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
+	"go.opentelemetry.io/contrib/instrumentation/go.mongodb.org/mongo-driver/mongo/otelmongo"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc/filters"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"google.golang.org/grpc/stats"
+)
+
+// Setup installs the global tracer provider of the named service, exporting spans as selected by
+// OTEL_TRACES_EXPORTER: "otlp" to the collector at OTEL_EXPORTER_OTLP_ENDPOINT over gRPC, "stdout" for
+// local debugging, or "none", the default, disabling tracing. The other standard OTEL_* variables, such
+// as OTEL_TRACES_SAMPLER or OTEL_SERVICE_NAME, apply too. The returned function flushes pending spans.
+func Setup(ctx context.Context, service string) (func(context.Context) error, error) {
+	// traceparent is honored even when tracing is disabled, keeping upstream traces connected
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	var exporter sdktrace.SpanExporter
+	var err error
+	switch kind := config.GetEnv("OTEL_TRACES_EXPORTER", "none"); kind {
+	case "none":
+		return func(context.Context) error { return nil }, nil
+	case "otlp":
+		exporter, err = otlptracegrpc.New(ctx)
+	case "stdout", "console":
+		exporter, err = stdouttrace.New(stdouttrace.WithPrettyPrint())
+	default:
+		return nil, fmt.Errorf("unsupported OTEL_TRACES_EXPORTER %q, expected otlp, stdout or none", kind)
+	}
+	if err != nil {
+		return nil, err
+	}
+	res, err := resource.New(ctx,
+		resource.WithAttributes(attribute.String("service.name", service)),
+		resource.WithFromEnv(),
+		resource.WithTelemetrySDK())
+	if err != nil {
+		return nil, err
+	}
+	provider := sdktrace.NewTracerProvider(sdktrace.WithBatcher(exporter), sdktrace.WithResource(res))
+	otel.SetTracerProvider(provider)
+	return provider.Shutdown, nil
+}
+
+// GinMiddleware is a Gin middleware starting a span per request, continuing the trace of its
+// traceparent header if any.
+func GinMiddleware(service string) gin.HandlerFunc {
+	return otelgin.Middleware(service)
+}
+
+// ClientHandler returns the gRPC stats handler starting a client span per call and propagating it
+// to the server in the call's metadata.
+func ClientHandler() stats.Handler {
+	return otelgrpc.NewClientHandler()
+}
+
+// ServerHandler returns the gRPC stats handler starting a server span per call, continuing the trace
+// propagated by the client. Health checks aren't traced.
+func ServerHandler() stats.Handler {
+	return otelgrpc.NewServerHandler(otelgrpc.WithFilter(filters.Not(filters.HealthCheck())))
+}
+
+// MongoMonitor returns a MongoDB command monitor starting a child span per command, without recording
+// the commands' contents. The events are forwarded to the other monitors too.
+func MongoMonitor(others ...*event.CommandMonitor) *event.CommandMonitor {
+	monitors := append([]*event.CommandMonitor{otelmongo.NewMonitor()}, others...)
+	return &event.CommandMonitor{
+		Started: func(ctx context.Context, e *event.CommandStartedEvent) {
+			for _, m := range monitors {
+				if m.Started != nil {
+					m.Started(ctx, e)
+				}
+			}
+		},
+		Succeeded: func(ctx context.Context, e *event.CommandSucceededEvent) {
+			for _, m := range monitors {
+				if m.Succeeded != nil {
+					m.Succeeded(ctx, e)
+				}
+			}
+		},
+		Failed: func(ctx context.Context, e *event.CommandFailedEvent) {
+			for _, m := range monitors {
+				if m.Failed != nil {
+					m.Failed(ctx, e)
+				}
+			}
+		},
+	}
+}
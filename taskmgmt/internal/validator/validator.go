@@ -2,12 +2,22 @@ package validator
 
 import (
 	"errors"
+	"fmt"
+	"mime"
+	"net/mail"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/Cohen-J-Omer/k8-task-mgmt-system/taskmgmt/internal/rbac"
+	"github.com/Cohen-J-Omer/k8-task-mgmt-system/taskmgmt/internal/recurrence"
 	pb "github.com/Cohen-J-Omer/k8-task-mgmt-system/taskmgmt/proto"
 )
 
 // ValidateTaskCreate validates the task creation/update request.
-// It checks that the title and description are not empty and within length limits.
+// It checks that the title and description are not empty and within length limits,
+// and that the due date and recurrence rule are well formed. Due dates are normalized to UTC.
 func ValidateTaskCreate(task *pb.Task) error {
 	if task.Title == "" {
 		return errors.New("title is required")
@@ -21,5 +31,409 @@ func ValidateTaskCreate(task *pb.Task) error {
 	if len(task.Description) > 1000 {
 		return errors.New("description must be at most 1000 characters")
 	}
+	if len(task.Checklist) > MaxChecklistItems {
+		return fmt.Errorf("checklist must have at most %d items", MaxChecklistItems)
+	}
+	for _, item := range task.Checklist {
+		if err := ValidateChecklistItem(item); err != nil {
+			return err
+		}
+	}
+	if task.Acl != nil {
+		if err := ValidateTaskACL(task.Acl); err != nil {
+			return err
+		}
+	}
+	if task.DueDate != "" {
+		due, err := time.Parse(time.RFC3339, task.DueDate)
+		if err != nil {
+			return errors.New("due_date must be an RFC 3339 timestamp")
+		}
+		// store due dates in UTC so they sort and compare as strings
+		task.DueDate = due.UTC().Format(time.RFC3339)
+	}
+	if task.Recurrence != "" {
+		if task.DueDate == "" {
+			return errors.New("due_date is required for recurring tasks")
+		}
+		if _, err := recurrence.Parse(task.Recurrence); err != nil {
+			return err
+		}
+	}
+	if len(task.Labels) > 20 {
+		return errors.New("a task must have at most 20 labels")
+	}
+	for _, label := range task.Labels {
+		if label == "" || len(label) > 50 {
+			return errors.New("labels must be 1-50 characters")
+		}
+	}
+	if task.EstimateSeconds < 0 {
+		return errors.New("estimate_seconds must not be negative")
+	}
+	if len(task.Status) > 50 {
+		return errors.New("status must be at most 50 characters")
+	}
+	if task.StoryPoints < 0 || task.StoryPoints > 1000 {
+		return errors.New("story_points must be between 0 and 1000")
+	}
+	return nil
+}
+
+// MaxWorklogDuration is the maximum duration of a single worklog entry.
+const MaxWorklogDuration = 7 * 24 * time.Hour
+
+// ValidateWorklog validates a manual worklog entry. An empty user and date are left for the backend
+// to default to the caller and now, other dates are normalized to UTC.
+func ValidateWorklog(w *pb.Worklog) error {
+	if w == nil {
+		return errors.New("worklog is required")
+	}
+	if w.DurationSeconds <= 0 || time.Duration(w.DurationSeconds)*time.Second > MaxWorklogDuration {
+		return fmt.Errorf("duration_seconds must be positive and at most %d", int64(MaxWorklogDuration/time.Second))
+	}
+	if len(w.Note) > 500 {
+		return errors.New("note must be at most 500 characters")
+	}
+	if w.Date != "" {
+		date, err := time.Parse(time.RFC3339, w.Date)
+		if err != nil {
+			return errors.New("date must be an RFC 3339 timestamp")
+		}
+		w.Date = date.UTC().Format(time.RFC3339)
+	}
+	return nil
+}
+
+// MaxChecklistItems is the maximum number of checklist items of a single task.
+const MaxChecklistItems = 100
+
+// ValidateChecklistItem validates a checklist item's text is not empty and within length limits.
+func ValidateChecklistItem(item *pb.ChecklistItem) error {
+	if item == nil || item.Text == "" {
+		return errors.New("checklist item text is required")
+	}
+	if len(item.Text) > 200 {
+		return errors.New("checklist item text must be at most 200 characters")
+	}
+	return nil
+}
+
+// AttachmentLimits restricts the attachments accepted for upload.
+type AttachmentLimits struct {
+	MaxBytes     int64    // maximum size of a single attachment
+	AllowedTypes []string // allowed media types, a "type/*" entry allows every subtype
+}
+
+var (
+	// ErrAttachmentTooLarge is returned by ValidateAttachment for attachments above the size limit.
+	ErrAttachmentTooLarge = errors.New("attachment exceeds maximum size")
+	// ErrAttachmentType is returned by ValidateAttachment for content types that are not allowed.
+	ErrAttachmentType = errors.New("attachment content type is not allowed")
+)
+
+// AttachmentTooLargeReason is the ErrorInfo reason of the backend's errors for attachments above the
+// size limit, which the API maps to 413 rather than the 400 of other invalid arguments.
+const AttachmentTooLargeReason = "ATTACHMENT_TOO_LARGE"
+
+// ValidateAttachment validates an attachment's file name, size and sniffed content type against limits.
+// It returns ErrAttachmentTooLarge or ErrAttachmentType for limit violations so callers can map them to a status code.
+func ValidateAttachment(name string, size int64, contentType string, limits AttachmentLimits) error {
+	if name == "" || name == "." || name == "/" {
+		return errors.New("file name is required")
+	}
+	if len(name) > 255 {
+		return errors.New("file name must be at most 255 characters")
+	}
+	if size > limits.MaxBytes {
+		return fmt.Errorf("%w of %d bytes", ErrAttachmentTooLarge, limits.MaxBytes)
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrAttachmentType, contentType)
+	}
+	for _, allowed := range limits.AllowedTypes {
+		if allowed == mediaType || (strings.HasSuffix(allowed, "/*") && strings.HasPrefix(mediaType, strings.TrimSuffix(allowed, "*"))) {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %s", ErrAttachmentType, mediaType)
+}
+
+const (
+	// MaxTemplateTasks is the maximum number of tasks in a template's task tree.
+	MaxTemplateTasks = 100
+	// MaxTemplateDepth is the maximum nesting depth of a template's task tree, the root being at depth 1.
+	MaxTemplateDepth = 5
+)
+
+var templateNameRe = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,63}$`)
+
+// ValidateTemplate validates a template's name and task tree.
+// Titles and descriptions may hold {{variables}}, so their length limits are only checked on instantiation.
+func ValidateTemplate(tmpl *pb.TaskTemplate) error {
+	if !templateNameRe.MatchString(tmpl.Name) {
+		return errors.New("name must be 1-64 lowercase letters, digits, '-' or '_'")
+	}
+	if len(tmpl.Description) > 1000 {
+		return errors.New("description must be at most 1000 characters")
+	}
+	if tmpl.Root == nil {
+		return errors.New("root task is required")
+	}
+	count := 0
+	return validateTemplateTask(tmpl.Root, 1, &count)
+}
+
+func validateTemplateTask(task *pb.TemplateTask, depth int, count *int) error {
+	*count++
+	if *count > MaxTemplateTasks {
+		return fmt.Errorf("template must have at most %d tasks", MaxTemplateTasks)
+	}
+	if depth > MaxTemplateDepth {
+		return fmt.Errorf("template tasks must be nested at most %d levels deep", MaxTemplateDepth)
+	}
+	if task.Title == "" {
+		return errors.New("title is required")
+	}
+	if task.Description == "" {
+		return errors.New("description is required")
+	}
+	if task.DueIn != "" {
+		if _, err := ParseRelativeDuration(task.DueIn); err != nil {
+			return err
+		}
+	}
+	if task.Recurrence != "" {
+		if task.DueIn == "" {
+			return errors.New("due_in is required for recurring template tasks")
+		}
+		if _, err := recurrence.Parse(task.Recurrence); err != nil {
+			return err
+		}
+	}
+	for _, sub := range task.Subtasks {
+		if err := validateTemplateTask(sub, depth+1, count); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ParseRelativeDuration parses a duration relative to a base date. Besides Go durations such as "36h",
+// it accepts whole days and weeks such as "3d" or "-1w".
+func ParseRelativeDuration(s string) (time.Duration, error) {
+	if n := len(s); n > 1 && (s[n-1] == 'd' || s[n-1] == 'w') {
+		count, err := strconv.Atoi(s[:n-1])
+		if err != nil {
+			return 0, fmt.Errorf("invalid relative duration %q", s)
+		}
+		unit := 24 * time.Hour
+		if s[n-1] == 'w' {
+			unit *= 7
+		}
+		return time.Duration(count) * unit, nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid relative duration %q", s)
+	}
+	return d, nil
+}
+
+var userIDRe = regexp.MustCompile(`^[a-z0-9][a-z0-9._-]{0,63}$`)
+
+// ValidateUser validates a user's ID, name, optional email address and role, which defaults to member.
+func ValidateUser(user *pb.User) error {
+	if !userIDRe.MatchString(user.Id) {
+		return errors.New("id must be 1-64 lowercase letters, digits, '.', '-' or '_'")
+	}
+	// "me" refers to the caller and "admin" to the bootstrap admin key
+	if user.Id == "me" || user.Id == "admin" {
+		return fmt.Errorf("id %q is reserved", user.Id)
+	}
+	if user.Name == "" {
+		return errors.New("name is required")
+	}
+	if len(user.Name) > 100 {
+		return errors.New("name must be at most 100 characters")
+	}
+	if user.Email != "" {
+		if _, err := mail.ParseAddress(user.Email); err != nil {
+			return errors.New("email must be a valid email address")
+		}
+	}
+	if user.Role == "" {
+		user.Role = rbac.Member
+	}
+	if !rbac.ValidRole(user.Role) {
+		return errors.New("role must be viewer, member or admin")
+	}
+	return nil
+}
+
+// ValidateTeam validates a team's ID and name.
+func ValidateTeam(team *pb.Team) error {
+	if !userIDRe.MatchString(team.Id) {
+		return errors.New("id must be 1-64 lowercase letters, digits, '.', '-' or '_'")
+	}
+	if team.Name == "" {
+		return errors.New("name is required")
+	}
+	if len(team.Name) > 100 {
+		return errors.New("name must be at most 100 characters")
+	}
+	return nil
+}
+
+// ValidateAPIKey validates a new API key's owner, name and optional expiry, which must be in the future.
+// The expiry is normalized to UTC.
+func ValidateAPIKey(key *pb.APIKey) error {
+	if key.UserId == "" {
+		return errors.New("user_id is required")
+	}
+	if len(key.Name) > 100 {
+		return errors.New("name must be at most 100 characters")
+	}
+	if key.ExpiresAt != "" {
+		t, err := time.Parse(time.RFC3339, key.ExpiresAt)
+		if err != nil {
+			return errors.New("expires_at must be an RFC 3339 timestamp")
+		}
+		if !t.After(time.Now()) {
+			return errors.New("expires_at must be in the future")
+		}
+		key.ExpiresAt = t.UTC().Format(time.RFC3339)
+	}
+	return nil
+}
+
+// MaxACLEntries limits each list of a task's access control list.
+const MaxACLEntries = 100
+
+// ValidateTaskACL validates the size of a task's access control lists.
+func ValidateTaskACL(acl *pb.TaskACL) error {
+	lists := []struct {
+		name string
+		ids  []string
+	}{
+		{"reader_ids", acl.ReaderIds},
+		{"writer_ids", acl.WriterIds},
+		{"reader_team_ids", acl.ReaderTeamIds},
+		{"writer_team_ids", acl.WriterTeamIds},
+	}
+	for _, list := range lists {
+		if len(list.ids) > MaxACLEntries {
+			return fmt.Errorf("acl %s must have at most %d entries", list.name, MaxACLEntries)
+		}
+		for _, id := range list.ids {
+			if id == "" {
+				return fmt.Errorf("acl %s must not contain empty IDs", list.name)
+			}
+		}
+	}
+	return nil
+}
+
+// ValidateWorkspace validates a workspace's ID and name.
+func ValidateWorkspace(ws *pb.Workspace) error {
+	if !templateNameRe.MatchString(ws.Id) {
+		return errors.New("id must be 1-64 lowercase letters, digits, '-' or '_'")
+	}
+	if ws.Name == "" {
+		return errors.New("name is required")
+	}
+	if len(ws.Name) > 100 {
+		return errors.New("name must be at most 100 characters")
+	}
+	return nil
+}
+
+// ValidateProject validates a project's name and description.
+func ValidateProject(project *pb.Project) error {
+	if project.Name == "" {
+		return errors.New("name is required")
+	}
+	if len(project.Name) > 100 {
+		return errors.New("name must be at most 100 characters")
+	}
+	if len(project.Description) > 1000 {
+		return errors.New("description must be at most 1000 characters")
+	}
+	return nil
+}
+
+// MaxBoardColumns is the maximum number of columns of a board.
+const MaxBoardColumns = 20
+
+// ValidateBoard validates a board's project, name and columns. Column names must be unique,
+// and each status may only be mapped to a single column.
+func ValidateBoard(board *pb.Board) error {
+	if board.ProjectId == "" {
+		return errors.New("project_id is required")
+	}
+	if board.Name == "" {
+		return errors.New("name is required")
+	}
+	if len(board.Name) > 100 {
+		return errors.New("name must be at most 100 characters")
+	}
+	if len(board.Columns) == 0 || len(board.Columns) > MaxBoardColumns {
+		return fmt.Errorf("a board must have 1-%d columns", MaxBoardColumns)
+	}
+	names := map[string]bool{}
+	statuses := map[string]bool{}
+	for _, col := range board.Columns {
+		if col.Name == "" || len(col.Name) > 50 {
+			return errors.New("column names must be 1-50 characters")
+		}
+		if names[col.Name] {
+			return fmt.Errorf("duplicate column %q", col.Name)
+		}
+		names[col.Name] = true
+		if len(col.Statuses) == 0 {
+			return fmt.Errorf("column %q must have at least one status", col.Name)
+		}
+		for _, s := range col.Statuses {
+			if s == "" || len(s) > 50 {
+				return errors.New("statuses must be 1-50 characters")
+			}
+			if statuses[s] {
+				return fmt.Errorf("status %q is mapped to more than one column", s)
+			}
+			statuses[s] = true
+		}
+		if col.WipLimit < 0 {
+			return fmt.Errorf("column %q must not have a negative wip_limit", col.Name)
+		}
+	}
+	return nil
+}
+
+// MaxMilestoneDays is the maximum length of a milestone, bounding its burndown chart.
+const MaxMilestoneDays = 366
+
+// ValidateMilestone validates a milestone's name and its start and end dates.
+func ValidateMilestone(m *pb.Milestone) error {
+	if m.Name == "" {
+		return errors.New("name is required")
+	}
+	if len(m.Name) > 100 {
+		return errors.New("name must be at most 100 characters")
+	}
+	start, err := time.Parse(time.DateOnly, m.StartDate)
+	if err != nil {
+		return errors.New("start_date must be a YYYY-MM-DD date")
+	}
+	end, err := time.Parse(time.DateOnly, m.EndDate)
+	if err != nil {
+		return errors.New("end_date must be a YYYY-MM-DD date")
+	}
+	if end.Before(start) {
+		return errors.New("end_date must not be before start_date")
+	}
+	if end.Sub(start) >= MaxMilestoneDays*24*time.Hour {
+		return fmt.Errorf("a milestone must last at most %d days", MaxMilestoneDays)
+	}
 	return nil
-}
\ No newline at end of file
+}
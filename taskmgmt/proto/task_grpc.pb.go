@@ -19,11 +19,30 @@ import (
 const _ = grpc.SupportPackageIsVersion8
 
 const (
-	TaskService_CreateTask_FullMethodName = "/task.TaskService/CreateTask"
-	TaskService_GetTask_FullMethodName    = "/task.TaskService/GetTask"
-	TaskService_GetTasks_FullMethodName   = "/task.TaskService/GetTasks"
-	TaskService_UpdateTask_FullMethodName = "/task.TaskService/UpdateTask"
-	TaskService_DeleteTask_FullMethodName = "/task.TaskService/DeleteTask"
+	TaskService_CreateTask_FullMethodName          = "/task.TaskService/CreateTask"
+	TaskService_GetTask_FullMethodName             = "/task.TaskService/GetTask"
+	TaskService_GetTasks_FullMethodName            = "/task.TaskService/GetTasks"
+	TaskService_UpdateTask_FullMethodName          = "/task.TaskService/UpdateTask"
+	TaskService_DeleteTask_FullMethodName          = "/task.TaskService/DeleteTask"
+	TaskService_UploadAttachment_FullMethodName    = "/task.TaskService/UploadAttachment"
+	TaskService_DownloadAttachment_FullMethodName  = "/task.TaskService/DownloadAttachment"
+	TaskService_DeleteAttachment_FullMethodName    = "/task.TaskService/DeleteAttachment"
+	TaskService_AddChecklistItem_FullMethodName    = "/task.TaskService/AddChecklistItem"
+	TaskService_ToggleChecklistItem_FullMethodName = "/task.TaskService/ToggleChecklistItem"
+	TaskService_ReorderChecklist_FullMethodName    = "/task.TaskService/ReorderChecklist"
+	TaskService_RemoveChecklistItem_FullMethodName = "/task.TaskService/RemoveChecklistItem"
+	TaskService_ListOccurrences_FullMethodName     = "/task.TaskService/ListOccurrences"
+	TaskService_StartTimer_FullMethodName          = "/task.TaskService/StartTimer"
+	TaskService_StopTimer_FullMethodName           = "/task.TaskService/StopTimer"
+	TaskService_AddWorklog_FullMethodName          = "/task.TaskService/AddWorklog"
+	TaskService_DeleteWorklog_FullMethodName       = "/task.TaskService/DeleteWorklog"
+	TaskService_GetTimeReport_FullMethodName       = "/task.TaskService/GetTimeReport"
+	TaskService_AddAssignee_FullMethodName         = "/task.TaskService/AddAssignee"
+	TaskService_RemoveAssignee_FullMethodName      = "/task.TaskService/RemoveAssignee"
+	TaskService_AddWatcher_FullMethodName          = "/task.TaskService/AddWatcher"
+	TaskService_RemoveWatcher_FullMethodName       = "/task.TaskService/RemoveWatcher"
+	TaskService_SetTaskACL_FullMethodName          = "/task.TaskService/SetTaskACL"
+	TaskService_GetTaskAccess_FullMethodName       = "/task.TaskService/GetTaskAccess"
 )
 
 // TaskServiceClient is the client API for TaskService service.
@@ -32,9 +51,32 @@ const (
 type TaskServiceClient interface {
 	CreateTask(ctx context.Context, in *Task, opts ...grpc.CallOption) (*Task, error)
 	GetTask(ctx context.Context, in *TaskID, opts ...grpc.CallOption) (*Task, error)
-	GetTasks(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*TaskList, error)
+	GetTasks(ctx context.Context, in *TaskFilter, opts ...grpc.CallOption) (*TaskList, error)
 	UpdateTask(ctx context.Context, in *Task, opts ...grpc.CallOption) (*Task, error)
 	DeleteTask(ctx context.Context, in *TaskID, opts ...grpc.CallOption) (*Task, error)
+	// the first message carries the attachment's metadata, the following ones its content
+	UploadAttachment(ctx context.Context, opts ...grpc.CallOption) (TaskService_UploadAttachmentClient, error)
+	DownloadAttachment(ctx context.Context, in *AttachmentRange, opts ...grpc.CallOption) (TaskService_DownloadAttachmentClient, error)
+	DeleteAttachment(ctx context.Context, in *AttachmentID, opts ...grpc.CallOption) (*Attachment, error)
+	AddChecklistItem(ctx context.Context, in *ChecklistItemRequest, opts ...grpc.CallOption) (*Task, error)
+	ToggleChecklistItem(ctx context.Context, in *ChecklistItemID, opts ...grpc.CallOption) (*Task, error)
+	ReorderChecklist(ctx context.Context, in *ChecklistOrder, opts ...grpc.CallOption) (*Task, error)
+	RemoveChecklistItem(ctx context.Context, in *ChecklistItemID, opts ...grpc.CallOption) (*Task, error)
+	ListOccurrences(ctx context.Context, in *OccurrenceRange, opts ...grpc.CallOption) (*OccurrenceList, error)
+	StartTimer(ctx context.Context, in *TimerRequest, opts ...grpc.CallOption) (*Task, error)
+	// stops the user's running timer, logging the elapsed time as a worklog
+	StopTimer(ctx context.Context, in *TimerRequest, opts ...grpc.CallOption) (*Task, error)
+	AddWorklog(ctx context.Context, in *WorklogRequest, opts ...grpc.CallOption) (*Task, error)
+	DeleteWorklog(ctx context.Context, in *WorklogID, opts ...grpc.CallOption) (*Task, error)
+	GetTimeReport(ctx context.Context, in *TimeReportRequest, opts ...grpc.CallOption) (*TimeReport, error)
+	AddAssignee(ctx context.Context, in *TaskUser, opts ...grpc.CallOption) (*Task, error)
+	RemoveAssignee(ctx context.Context, in *TaskUser, opts ...grpc.CallOption) (*Task, error)
+	AddWatcher(ctx context.Context, in *TaskUser, opts ...grpc.CallOption) (*Task, error)
+	RemoveWatcher(ctx context.Context, in *TaskUser, opts ...grpc.CallOption) (*Task, error)
+	// only the task's owner and admins may change its access control list
+	SetTaskACL(ctx context.Context, in *TaskACLRequest, opts ...grpc.CallOption) (*Task, error)
+	// lists who can access the task, expanding teams into their members
+	GetTaskAccess(ctx context.Context, in *TaskID, opts ...grpc.CallOption) (*TaskAccess, error)
 }
 
 type taskServiceClient struct {
@@ -65,7 +107,7 @@ func (c *taskServiceClient) GetTask(ctx context.Context, in *TaskID, opts ...grp
 	return out, nil
 }
 
-func (c *taskServiceClient) GetTasks(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*TaskList, error) {
+func (c *taskServiceClient) GetTasks(ctx context.Context, in *TaskFilter, opts ...grpc.CallOption) (*TaskList, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(TaskList)
 	err := c.cc.Invoke(ctx, TaskService_GetTasks_FullMethodName, in, out, cOpts...)
@@ -95,15 +137,276 @@ func (c *taskServiceClient) DeleteTask(ctx context.Context, in *TaskID, opts ...
 	return out, nil
 }
 
+func (c *taskServiceClient) UploadAttachment(ctx context.Context, opts ...grpc.CallOption) (TaskService_UploadAttachmentClient, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &TaskService_ServiceDesc.Streams[0], TaskService_UploadAttachment_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &taskServiceUploadAttachmentClient{stream}
+	return x, nil
+}
+
+type TaskService_UploadAttachmentClient interface {
+	Send(*AttachmentChunk) error
+	CloseAndRecv() (*Attachment, error)
+	grpc.ClientStream
+}
+
+type taskServiceUploadAttachmentClient struct {
+	grpc.ClientStream
+}
+
+func (x *taskServiceUploadAttachmentClient) Send(m *AttachmentChunk) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *taskServiceUploadAttachmentClient) CloseAndRecv() (*Attachment, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(Attachment)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *taskServiceClient) DownloadAttachment(ctx context.Context, in *AttachmentRange, opts ...grpc.CallOption) (TaskService_DownloadAttachmentClient, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &TaskService_ServiceDesc.Streams[1], TaskService_DownloadAttachment_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &taskServiceDownloadAttachmentClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type TaskService_DownloadAttachmentClient interface {
+	Recv() (*AttachmentChunk, error)
+	grpc.ClientStream
+}
+
+type taskServiceDownloadAttachmentClient struct {
+	grpc.ClientStream
+}
+
+func (x *taskServiceDownloadAttachmentClient) Recv() (*AttachmentChunk, error) {
+	m := new(AttachmentChunk)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *taskServiceClient) DeleteAttachment(ctx context.Context, in *AttachmentID, opts ...grpc.CallOption) (*Attachment, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Attachment)
+	err := c.cc.Invoke(ctx, TaskService_DeleteAttachment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *taskServiceClient) AddChecklistItem(ctx context.Context, in *ChecklistItemRequest, opts ...grpc.CallOption) (*Task, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Task)
+	err := c.cc.Invoke(ctx, TaskService_AddChecklistItem_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *taskServiceClient) ToggleChecklistItem(ctx context.Context, in *ChecklistItemID, opts ...grpc.CallOption) (*Task, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Task)
+	err := c.cc.Invoke(ctx, TaskService_ToggleChecklistItem_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *taskServiceClient) ReorderChecklist(ctx context.Context, in *ChecklistOrder, opts ...grpc.CallOption) (*Task, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Task)
+	err := c.cc.Invoke(ctx, TaskService_ReorderChecklist_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *taskServiceClient) RemoveChecklistItem(ctx context.Context, in *ChecklistItemID, opts ...grpc.CallOption) (*Task, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Task)
+	err := c.cc.Invoke(ctx, TaskService_RemoveChecklistItem_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *taskServiceClient) ListOccurrences(ctx context.Context, in *OccurrenceRange, opts ...grpc.CallOption) (*OccurrenceList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(OccurrenceList)
+	err := c.cc.Invoke(ctx, TaskService_ListOccurrences_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *taskServiceClient) StartTimer(ctx context.Context, in *TimerRequest, opts ...grpc.CallOption) (*Task, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Task)
+	err := c.cc.Invoke(ctx, TaskService_StartTimer_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *taskServiceClient) StopTimer(ctx context.Context, in *TimerRequest, opts ...grpc.CallOption) (*Task, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Task)
+	err := c.cc.Invoke(ctx, TaskService_StopTimer_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *taskServiceClient) AddWorklog(ctx context.Context, in *WorklogRequest, opts ...grpc.CallOption) (*Task, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Task)
+	err := c.cc.Invoke(ctx, TaskService_AddWorklog_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *taskServiceClient) DeleteWorklog(ctx context.Context, in *WorklogID, opts ...grpc.CallOption) (*Task, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Task)
+	err := c.cc.Invoke(ctx, TaskService_DeleteWorklog_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *taskServiceClient) GetTimeReport(ctx context.Context, in *TimeReportRequest, opts ...grpc.CallOption) (*TimeReport, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TimeReport)
+	err := c.cc.Invoke(ctx, TaskService_GetTimeReport_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *taskServiceClient) AddAssignee(ctx context.Context, in *TaskUser, opts ...grpc.CallOption) (*Task, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Task)
+	err := c.cc.Invoke(ctx, TaskService_AddAssignee_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *taskServiceClient) RemoveAssignee(ctx context.Context, in *TaskUser, opts ...grpc.CallOption) (*Task, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Task)
+	err := c.cc.Invoke(ctx, TaskService_RemoveAssignee_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *taskServiceClient) AddWatcher(ctx context.Context, in *TaskUser, opts ...grpc.CallOption) (*Task, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Task)
+	err := c.cc.Invoke(ctx, TaskService_AddWatcher_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *taskServiceClient) RemoveWatcher(ctx context.Context, in *TaskUser, opts ...grpc.CallOption) (*Task, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Task)
+	err := c.cc.Invoke(ctx, TaskService_RemoveWatcher_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *taskServiceClient) SetTaskACL(ctx context.Context, in *TaskACLRequest, opts ...grpc.CallOption) (*Task, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Task)
+	err := c.cc.Invoke(ctx, TaskService_SetTaskACL_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *taskServiceClient) GetTaskAccess(ctx context.Context, in *TaskID, opts ...grpc.CallOption) (*TaskAccess, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TaskAccess)
+	err := c.cc.Invoke(ctx, TaskService_GetTaskAccess_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // TaskServiceServer is the server API for TaskService service.
 // All implementations must embed UnimplementedTaskServiceServer
 // for forward compatibility
 type TaskServiceServer interface {
 	CreateTask(context.Context, *Task) (*Task, error)
 	GetTask(context.Context, *TaskID) (*Task, error)
-	GetTasks(context.Context, *Empty) (*TaskList, error)
+	GetTasks(context.Context, *TaskFilter) (*TaskList, error)
 	UpdateTask(context.Context, *Task) (*Task, error)
 	DeleteTask(context.Context, *TaskID) (*Task, error)
+	// the first message carries the attachment's metadata, the following ones its content
+	UploadAttachment(TaskService_UploadAttachmentServer) error
+	DownloadAttachment(*AttachmentRange, TaskService_DownloadAttachmentServer) error
+	DeleteAttachment(context.Context, *AttachmentID) (*Attachment, error)
+	AddChecklistItem(context.Context, *ChecklistItemRequest) (*Task, error)
+	ToggleChecklistItem(context.Context, *ChecklistItemID) (*Task, error)
+	ReorderChecklist(context.Context, *ChecklistOrder) (*Task, error)
+	RemoveChecklistItem(context.Context, *ChecklistItemID) (*Task, error)
+	ListOccurrences(context.Context, *OccurrenceRange) (*OccurrenceList, error)
+	StartTimer(context.Context, *TimerRequest) (*Task, error)
+	// stops the user's running timer, logging the elapsed time as a worklog
+	StopTimer(context.Context, *TimerRequest) (*Task, error)
+	AddWorklog(context.Context, *WorklogRequest) (*Task, error)
+	DeleteWorklog(context.Context, *WorklogID) (*Task, error)
+	GetTimeReport(context.Context, *TimeReportRequest) (*TimeReport, error)
+	AddAssignee(context.Context, *TaskUser) (*Task, error)
+	RemoveAssignee(context.Context, *TaskUser) (*Task, error)
+	AddWatcher(context.Context, *TaskUser) (*Task, error)
+	RemoveWatcher(context.Context, *TaskUser) (*Task, error)
+	// only the task's owner and admins may change its access control list
+	SetTaskACL(context.Context, *TaskACLRequest) (*Task, error)
+	// lists who can access the task, expanding teams into their members
+	GetTaskAccess(context.Context, *TaskID) (*TaskAccess, error)
 	mustEmbedUnimplementedTaskServiceServer()
 }
 
@@ -117,7 +420,7 @@ func (UnimplementedTaskServiceServer) CreateTask(context.Context, *Task) (*Task,
 func (UnimplementedTaskServiceServer) GetTask(context.Context, *TaskID) (*Task, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetTask not implemented")
 }
-func (UnimplementedTaskServiceServer) GetTasks(context.Context, *Empty) (*TaskList, error) {
+func (UnimplementedTaskServiceServer) GetTasks(context.Context, *TaskFilter) (*TaskList, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetTasks not implemented")
 }
 func (UnimplementedTaskServiceServer) UpdateTask(context.Context, *Task) (*Task, error) {
@@ -126,6 +429,63 @@ func (UnimplementedTaskServiceServer) UpdateTask(context.Context, *Task) (*Task,
 func (UnimplementedTaskServiceServer) DeleteTask(context.Context, *TaskID) (*Task, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteTask not implemented")
 }
+func (UnimplementedTaskServiceServer) UploadAttachment(TaskService_UploadAttachmentServer) error {
+	return status.Errorf(codes.Unimplemented, "method UploadAttachment not implemented")
+}
+func (UnimplementedTaskServiceServer) DownloadAttachment(*AttachmentRange, TaskService_DownloadAttachmentServer) error {
+	return status.Errorf(codes.Unimplemented, "method DownloadAttachment not implemented")
+}
+func (UnimplementedTaskServiceServer) DeleteAttachment(context.Context, *AttachmentID) (*Attachment, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteAttachment not implemented")
+}
+func (UnimplementedTaskServiceServer) AddChecklistItem(context.Context, *ChecklistItemRequest) (*Task, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddChecklistItem not implemented")
+}
+func (UnimplementedTaskServiceServer) ToggleChecklistItem(context.Context, *ChecklistItemID) (*Task, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ToggleChecklistItem not implemented")
+}
+func (UnimplementedTaskServiceServer) ReorderChecklist(context.Context, *ChecklistOrder) (*Task, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReorderChecklist not implemented")
+}
+func (UnimplementedTaskServiceServer) RemoveChecklistItem(context.Context, *ChecklistItemID) (*Task, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveChecklistItem not implemented")
+}
+func (UnimplementedTaskServiceServer) ListOccurrences(context.Context, *OccurrenceRange) (*OccurrenceList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListOccurrences not implemented")
+}
+func (UnimplementedTaskServiceServer) StartTimer(context.Context, *TimerRequest) (*Task, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StartTimer not implemented")
+}
+func (UnimplementedTaskServiceServer) StopTimer(context.Context, *TimerRequest) (*Task, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StopTimer not implemented")
+}
+func (UnimplementedTaskServiceServer) AddWorklog(context.Context, *WorklogRequest) (*Task, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddWorklog not implemented")
+}
+func (UnimplementedTaskServiceServer) DeleteWorklog(context.Context, *WorklogID) (*Task, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteWorklog not implemented")
+}
+func (UnimplementedTaskServiceServer) GetTimeReport(context.Context, *TimeReportRequest) (*TimeReport, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTimeReport not implemented")
+}
+func (UnimplementedTaskServiceServer) AddAssignee(context.Context, *TaskUser) (*Task, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddAssignee not implemented")
+}
+func (UnimplementedTaskServiceServer) RemoveAssignee(context.Context, *TaskUser) (*Task, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveAssignee not implemented")
+}
+func (UnimplementedTaskServiceServer) AddWatcher(context.Context, *TaskUser) (*Task, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddWatcher not implemented")
+}
+func (UnimplementedTaskServiceServer) RemoveWatcher(context.Context, *TaskUser) (*Task, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveWatcher not implemented")
+}
+func (UnimplementedTaskServiceServer) SetTaskACL(context.Context, *TaskACLRequest) (*Task, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetTaskACL not implemented")
+}
+func (UnimplementedTaskServiceServer) GetTaskAccess(context.Context, *TaskID) (*TaskAccess, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTaskAccess not implemented")
+}
 func (UnimplementedTaskServiceServer) mustEmbedUnimplementedTaskServiceServer() {}
 
 // UnsafeTaskServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -176,7 +536,7 @@ func _TaskService_GetTask_Handler(srv interface{}, ctx context.Context, dec func
 }
 
 func _TaskService_GetTasks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(Empty)
+	in := new(TaskFilter)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
@@ -188,7 +548,7 @@ func _TaskService_GetTasks_Handler(srv interface{}, ctx context.Context, dec fun
 		FullMethod: TaskService_GetTasks_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(TaskServiceServer).GetTasks(ctx, req.(*Empty))
+		return srv.(TaskServiceServer).GetTasks(ctx, req.(*TaskFilter))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -229,32 +589,2622 @@ func _TaskService_DeleteTask_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
-// TaskService_ServiceDesc is the grpc.ServiceDesc for TaskService service.
-// It's only intended for direct use with grpc.RegisterService,
-// and not to be introspected or modified (even as a copy)
-var TaskService_ServiceDesc = grpc.ServiceDesc{
-	ServiceName: "task.TaskService",
-	HandlerType: (*TaskServiceServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "CreateTask",
-			Handler:    _TaskService_CreateTask_Handler,
-		},
-		{
-			MethodName: "GetTask",
-			Handler:    _TaskService_GetTask_Handler,
-		},
-		{
-			MethodName: "GetTasks",
-			Handler:    _TaskService_GetTasks_Handler,
-		},
-		{
-			MethodName: "UpdateTask",
-			Handler:    _TaskService_UpdateTask_Handler,
-		},
-		{
-			MethodName: "DeleteTask",
-			Handler:    _TaskService_DeleteTask_Handler,
+func _TaskService_UploadAttachment_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(TaskServiceServer).UploadAttachment(&taskServiceUploadAttachmentServer{stream})
+}
+
+type TaskService_UploadAttachmentServer interface {
+	SendAndClose(*Attachment) error
+	Recv() (*AttachmentChunk, error)
+	grpc.ServerStream
+}
+
+type taskServiceUploadAttachmentServer struct {
+	grpc.ServerStream
+}
+
+func (x *taskServiceUploadAttachmentServer) SendAndClose(m *Attachment) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *taskServiceUploadAttachmentServer) Recv() (*AttachmentChunk, error) {
+	m := new(AttachmentChunk)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _TaskService_DownloadAttachment_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(AttachmentRange)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TaskServiceServer).DownloadAttachment(m, &taskServiceDownloadAttachmentServer{stream})
+}
+
+type TaskService_DownloadAttachmentServer interface {
+	Send(*AttachmentChunk) error
+	grpc.ServerStream
+}
+
+type taskServiceDownloadAttachmentServer struct {
+	grpc.ServerStream
+}
+
+func (x *taskServiceDownloadAttachmentServer) Send(m *AttachmentChunk) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _TaskService_DeleteAttachment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AttachmentID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TaskServiceServer).DeleteAttachment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TaskService_DeleteAttachment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TaskServiceServer).DeleteAttachment(ctx, req.(*AttachmentID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TaskService_AddChecklistItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChecklistItemRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TaskServiceServer).AddChecklistItem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TaskService_AddChecklistItem_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TaskServiceServer).AddChecklistItem(ctx, req.(*ChecklistItemRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TaskService_ToggleChecklistItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChecklistItemID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TaskServiceServer).ToggleChecklistItem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TaskService_ToggleChecklistItem_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TaskServiceServer).ToggleChecklistItem(ctx, req.(*ChecklistItemID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TaskService_ReorderChecklist_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChecklistOrder)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TaskServiceServer).ReorderChecklist(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TaskService_ReorderChecklist_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TaskServiceServer).ReorderChecklist(ctx, req.(*ChecklistOrder))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TaskService_RemoveChecklistItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChecklistItemID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TaskServiceServer).RemoveChecklistItem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TaskService_RemoveChecklistItem_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TaskServiceServer).RemoveChecklistItem(ctx, req.(*ChecklistItemID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TaskService_ListOccurrences_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OccurrenceRange)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TaskServiceServer).ListOccurrences(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TaskService_ListOccurrences_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TaskServiceServer).ListOccurrences(ctx, req.(*OccurrenceRange))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TaskService_StartTimer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TimerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TaskServiceServer).StartTimer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TaskService_StartTimer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TaskServiceServer).StartTimer(ctx, req.(*TimerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TaskService_StopTimer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TimerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TaskServiceServer).StopTimer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TaskService_StopTimer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TaskServiceServer).StopTimer(ctx, req.(*TimerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TaskService_AddWorklog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WorklogRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TaskServiceServer).AddWorklog(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TaskService_AddWorklog_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TaskServiceServer).AddWorklog(ctx, req.(*WorklogRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TaskService_DeleteWorklog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WorklogID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TaskServiceServer).DeleteWorklog(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TaskService_DeleteWorklog_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TaskServiceServer).DeleteWorklog(ctx, req.(*WorklogID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TaskService_GetTimeReport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TimeReportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TaskServiceServer).GetTimeReport(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TaskService_GetTimeReport_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TaskServiceServer).GetTimeReport(ctx, req.(*TimeReportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TaskService_AddAssignee_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TaskUser)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TaskServiceServer).AddAssignee(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TaskService_AddAssignee_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TaskServiceServer).AddAssignee(ctx, req.(*TaskUser))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TaskService_RemoveAssignee_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TaskUser)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TaskServiceServer).RemoveAssignee(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TaskService_RemoveAssignee_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TaskServiceServer).RemoveAssignee(ctx, req.(*TaskUser))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TaskService_AddWatcher_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TaskUser)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TaskServiceServer).AddWatcher(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TaskService_AddWatcher_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TaskServiceServer).AddWatcher(ctx, req.(*TaskUser))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TaskService_RemoveWatcher_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TaskUser)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TaskServiceServer).RemoveWatcher(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TaskService_RemoveWatcher_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TaskServiceServer).RemoveWatcher(ctx, req.(*TaskUser))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TaskService_SetTaskACL_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TaskACLRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TaskServiceServer).SetTaskACL(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TaskService_SetTaskACL_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TaskServiceServer).SetTaskACL(ctx, req.(*TaskACLRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TaskService_GetTaskAccess_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TaskID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TaskServiceServer).GetTaskAccess(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TaskService_GetTaskAccess_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TaskServiceServer).GetTaskAccess(ctx, req.(*TaskID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// TaskService_ServiceDesc is the grpc.ServiceDesc for TaskService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var TaskService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "task.TaskService",
+	HandlerType: (*TaskServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateTask",
+			Handler:    _TaskService_CreateTask_Handler,
+		},
+		{
+			MethodName: "GetTask",
+			Handler:    _TaskService_GetTask_Handler,
+		},
+		{
+			MethodName: "GetTasks",
+			Handler:    _TaskService_GetTasks_Handler,
+		},
+		{
+			MethodName: "UpdateTask",
+			Handler:    _TaskService_UpdateTask_Handler,
+		},
+		{
+			MethodName: "DeleteTask",
+			Handler:    _TaskService_DeleteTask_Handler,
+		},
+		{
+			MethodName: "DeleteAttachment",
+			Handler:    _TaskService_DeleteAttachment_Handler,
+		},
+		{
+			MethodName: "AddChecklistItem",
+			Handler:    _TaskService_AddChecklistItem_Handler,
+		},
+		{
+			MethodName: "ToggleChecklistItem",
+			Handler:    _TaskService_ToggleChecklistItem_Handler,
+		},
+		{
+			MethodName: "ReorderChecklist",
+			Handler:    _TaskService_ReorderChecklist_Handler,
+		},
+		{
+			MethodName: "RemoveChecklistItem",
+			Handler:    _TaskService_RemoveChecklistItem_Handler,
+		},
+		{
+			MethodName: "ListOccurrences",
+			Handler:    _TaskService_ListOccurrences_Handler,
+		},
+		{
+			MethodName: "StartTimer",
+			Handler:    _TaskService_StartTimer_Handler,
+		},
+		{
+			MethodName: "StopTimer",
+			Handler:    _TaskService_StopTimer_Handler,
+		},
+		{
+			MethodName: "AddWorklog",
+			Handler:    _TaskService_AddWorklog_Handler,
+		},
+		{
+			MethodName: "DeleteWorklog",
+			Handler:    _TaskService_DeleteWorklog_Handler,
+		},
+		{
+			MethodName: "GetTimeReport",
+			Handler:    _TaskService_GetTimeReport_Handler,
+		},
+		{
+			MethodName: "AddAssignee",
+			Handler:    _TaskService_AddAssignee_Handler,
+		},
+		{
+			MethodName: "RemoveAssignee",
+			Handler:    _TaskService_RemoveAssignee_Handler,
+		},
+		{
+			MethodName: "AddWatcher",
+			Handler:    _TaskService_AddWatcher_Handler,
+		},
+		{
+			MethodName: "RemoveWatcher",
+			Handler:    _TaskService_RemoveWatcher_Handler,
+		},
+		{
+			MethodName: "SetTaskACL",
+			Handler:    _TaskService_SetTaskACL_Handler,
+		},
+		{
+			MethodName: "GetTaskAccess",
+			Handler:    _TaskService_GetTaskAccess_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "UploadAttachment",
+			Handler:       _TaskService_UploadAttachment_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "DownloadAttachment",
+			Handler:       _TaskService_DownloadAttachment_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "task.proto",
+}
+
+const (
+	TemplateService_CreateTemplate_FullMethodName      = "/task.TemplateService/CreateTemplate"
+	TemplateService_GetTemplate_FullMethodName         = "/task.TemplateService/GetTemplate"
+	TemplateService_GetTemplates_FullMethodName        = "/task.TemplateService/GetTemplates"
+	TemplateService_UpdateTemplate_FullMethodName      = "/task.TemplateService/UpdateTemplate"
+	TemplateService_DeleteTemplate_FullMethodName      = "/task.TemplateService/DeleteTemplate"
+	TemplateService_InstantiateTemplate_FullMethodName = "/task.TemplateService/InstantiateTemplate"
+)
+
+// TemplateServiceClient is the client API for TemplateService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type TemplateServiceClient interface {
+	CreateTemplate(ctx context.Context, in *TaskTemplate, opts ...grpc.CallOption) (*TaskTemplate, error)
+	GetTemplate(ctx context.Context, in *TemplateName, opts ...grpc.CallOption) (*TaskTemplate, error)
+	GetTemplates(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*TemplateList, error)
+	UpdateTemplate(ctx context.Context, in *TaskTemplate, opts ...grpc.CallOption) (*TaskTemplate, error)
+	DeleteTemplate(ctx context.Context, in *TemplateName, opts ...grpc.CallOption) (*TaskTemplate, error)
+	// creates the template's task tree, parent first
+	InstantiateTemplate(ctx context.Context, in *InstantiateRequest, opts ...grpc.CallOption) (*TaskList, error)
+}
+
+type templateServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewTemplateServiceClient(cc grpc.ClientConnInterface) TemplateServiceClient {
+	return &templateServiceClient{cc}
+}
+
+func (c *templateServiceClient) CreateTemplate(ctx context.Context, in *TaskTemplate, opts ...grpc.CallOption) (*TaskTemplate, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TaskTemplate)
+	err := c.cc.Invoke(ctx, TemplateService_CreateTemplate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *templateServiceClient) GetTemplate(ctx context.Context, in *TemplateName, opts ...grpc.CallOption) (*TaskTemplate, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TaskTemplate)
+	err := c.cc.Invoke(ctx, TemplateService_GetTemplate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *templateServiceClient) GetTemplates(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*TemplateList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TemplateList)
+	err := c.cc.Invoke(ctx, TemplateService_GetTemplates_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *templateServiceClient) UpdateTemplate(ctx context.Context, in *TaskTemplate, opts ...grpc.CallOption) (*TaskTemplate, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TaskTemplate)
+	err := c.cc.Invoke(ctx, TemplateService_UpdateTemplate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *templateServiceClient) DeleteTemplate(ctx context.Context, in *TemplateName, opts ...grpc.CallOption) (*TaskTemplate, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TaskTemplate)
+	err := c.cc.Invoke(ctx, TemplateService_DeleteTemplate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *templateServiceClient) InstantiateTemplate(ctx context.Context, in *InstantiateRequest, opts ...grpc.CallOption) (*TaskList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TaskList)
+	err := c.cc.Invoke(ctx, TemplateService_InstantiateTemplate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// TemplateServiceServer is the server API for TemplateService service.
+// All implementations must embed UnimplementedTemplateServiceServer
+// for forward compatibility
+type TemplateServiceServer interface {
+	CreateTemplate(context.Context, *TaskTemplate) (*TaskTemplate, error)
+	GetTemplate(context.Context, *TemplateName) (*TaskTemplate, error)
+	GetTemplates(context.Context, *Empty) (*TemplateList, error)
+	UpdateTemplate(context.Context, *TaskTemplate) (*TaskTemplate, error)
+	DeleteTemplate(context.Context, *TemplateName) (*TaskTemplate, error)
+	// creates the template's task tree, parent first
+	InstantiateTemplate(context.Context, *InstantiateRequest) (*TaskList, error)
+	mustEmbedUnimplementedTemplateServiceServer()
+}
+
+// UnimplementedTemplateServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedTemplateServiceServer struct {
+}
+
+func (UnimplementedTemplateServiceServer) CreateTemplate(context.Context, *TaskTemplate) (*TaskTemplate, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateTemplate not implemented")
+}
+func (UnimplementedTemplateServiceServer) GetTemplate(context.Context, *TemplateName) (*TaskTemplate, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTemplate not implemented")
+}
+func (UnimplementedTemplateServiceServer) GetTemplates(context.Context, *Empty) (*TemplateList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTemplates not implemented")
+}
+func (UnimplementedTemplateServiceServer) UpdateTemplate(context.Context, *TaskTemplate) (*TaskTemplate, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateTemplate not implemented")
+}
+func (UnimplementedTemplateServiceServer) DeleteTemplate(context.Context, *TemplateName) (*TaskTemplate, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteTemplate not implemented")
+}
+func (UnimplementedTemplateServiceServer) InstantiateTemplate(context.Context, *InstantiateRequest) (*TaskList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method InstantiateTemplate not implemented")
+}
+func (UnimplementedTemplateServiceServer) mustEmbedUnimplementedTemplateServiceServer() {}
+
+// UnsafeTemplateServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to TemplateServiceServer will
+// result in compilation errors.
+type UnsafeTemplateServiceServer interface {
+	mustEmbedUnimplementedTemplateServiceServer()
+}
+
+func RegisterTemplateServiceServer(s grpc.ServiceRegistrar, srv TemplateServiceServer) {
+	s.RegisterService(&TemplateService_ServiceDesc, srv)
+}
+
+func _TemplateService_CreateTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TaskTemplate)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TemplateServiceServer).CreateTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TemplateService_CreateTemplate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TemplateServiceServer).CreateTemplate(ctx, req.(*TaskTemplate))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TemplateService_GetTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TemplateName)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TemplateServiceServer).GetTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TemplateService_GetTemplate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TemplateServiceServer).GetTemplate(ctx, req.(*TemplateName))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TemplateService_GetTemplates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TemplateServiceServer).GetTemplates(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TemplateService_GetTemplates_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TemplateServiceServer).GetTemplates(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TemplateService_UpdateTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TaskTemplate)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TemplateServiceServer).UpdateTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TemplateService_UpdateTemplate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TemplateServiceServer).UpdateTemplate(ctx, req.(*TaskTemplate))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TemplateService_DeleteTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TemplateName)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TemplateServiceServer).DeleteTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TemplateService_DeleteTemplate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TemplateServiceServer).DeleteTemplate(ctx, req.(*TemplateName))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TemplateService_InstantiateTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InstantiateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TemplateServiceServer).InstantiateTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TemplateService_InstantiateTemplate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TemplateServiceServer).InstantiateTemplate(ctx, req.(*InstantiateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// TemplateService_ServiceDesc is the grpc.ServiceDesc for TemplateService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var TemplateService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "task.TemplateService",
+	HandlerType: (*TemplateServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateTemplate",
+			Handler:    _TemplateService_CreateTemplate_Handler,
+		},
+		{
+			MethodName: "GetTemplate",
+			Handler:    _TemplateService_GetTemplate_Handler,
+		},
+		{
+			MethodName: "GetTemplates",
+			Handler:    _TemplateService_GetTemplates_Handler,
+		},
+		{
+			MethodName: "UpdateTemplate",
+			Handler:    _TemplateService_UpdateTemplate_Handler,
+		},
+		{
+			MethodName: "DeleteTemplate",
+			Handler:    _TemplateService_DeleteTemplate_Handler,
+		},
+		{
+			MethodName: "InstantiateTemplate",
+			Handler:    _TemplateService_InstantiateTemplate_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "task.proto",
+}
+
+const (
+	UserService_CreateUser_FullMethodName       = "/task.UserService/CreateUser"
+	UserService_GetUser_FullMethodName          = "/task.UserService/GetUser"
+	UserService_GetUsers_FullMethodName         = "/task.UserService/GetUsers"
+	UserService_UpdateUser_FullMethodName       = "/task.UserService/UpdateUser"
+	UserService_CreateTeam_FullMethodName       = "/task.UserService/CreateTeam"
+	UserService_GetTeam_FullMethodName          = "/task.UserService/GetTeam"
+	UserService_GetTeams_FullMethodName         = "/task.UserService/GetTeams"
+	UserService_AddTeamMember_FullMethodName    = "/task.UserService/AddTeamMember"
+	UserService_RemoveTeamMember_FullMethodName = "/task.UserService/RemoveTeamMember"
+)
+
+// UserServiceClient is the client API for UserService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type UserServiceClient interface {
+	CreateUser(ctx context.Context, in *User, opts ...grpc.CallOption) (*User, error)
+	GetUser(ctx context.Context, in *UserID, opts ...grpc.CallOption) (*User, error)
+	GetUsers(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*UserList, error)
+	UpdateUser(ctx context.Context, in *User, opts ...grpc.CallOption) (*User, error)
+	CreateTeam(ctx context.Context, in *Team, opts ...grpc.CallOption) (*Team, error)
+	GetTeam(ctx context.Context, in *TeamID, opts ...grpc.CallOption) (*Team, error)
+	GetTeams(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*TeamList, error)
+	AddTeamMember(ctx context.Context, in *TeamMember, opts ...grpc.CallOption) (*Team, error)
+	RemoveTeamMember(ctx context.Context, in *TeamMember, opts ...grpc.CallOption) (*Team, error)
+}
+
+type userServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewUserServiceClient(cc grpc.ClientConnInterface) UserServiceClient {
+	return &userServiceClient{cc}
+}
+
+func (c *userServiceClient) CreateUser(ctx context.Context, in *User, opts ...grpc.CallOption) (*User, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(User)
+	err := c.cc.Invoke(ctx, UserService_CreateUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) GetUser(ctx context.Context, in *UserID, opts ...grpc.CallOption) (*User, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(User)
+	err := c.cc.Invoke(ctx, UserService_GetUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) GetUsers(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*UserList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UserList)
+	err := c.cc.Invoke(ctx, UserService_GetUsers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) UpdateUser(ctx context.Context, in *User, opts ...grpc.CallOption) (*User, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(User)
+	err := c.cc.Invoke(ctx, UserService_UpdateUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) CreateTeam(ctx context.Context, in *Team, opts ...grpc.CallOption) (*Team, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Team)
+	err := c.cc.Invoke(ctx, UserService_CreateTeam_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) GetTeam(ctx context.Context, in *TeamID, opts ...grpc.CallOption) (*Team, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Team)
+	err := c.cc.Invoke(ctx, UserService_GetTeam_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) GetTeams(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*TeamList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TeamList)
+	err := c.cc.Invoke(ctx, UserService_GetTeams_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) AddTeamMember(ctx context.Context, in *TeamMember, opts ...grpc.CallOption) (*Team, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Team)
+	err := c.cc.Invoke(ctx, UserService_AddTeamMember_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) RemoveTeamMember(ctx context.Context, in *TeamMember, opts ...grpc.CallOption) (*Team, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Team)
+	err := c.cc.Invoke(ctx, UserService_RemoveTeamMember_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// UserServiceServer is the server API for UserService service.
+// All implementations must embed UnimplementedUserServiceServer
+// for forward compatibility
+type UserServiceServer interface {
+	CreateUser(context.Context, *User) (*User, error)
+	GetUser(context.Context, *UserID) (*User, error)
+	GetUsers(context.Context, *Empty) (*UserList, error)
+	UpdateUser(context.Context, *User) (*User, error)
+	CreateTeam(context.Context, *Team) (*Team, error)
+	GetTeam(context.Context, *TeamID) (*Team, error)
+	GetTeams(context.Context, *Empty) (*TeamList, error)
+	AddTeamMember(context.Context, *TeamMember) (*Team, error)
+	RemoveTeamMember(context.Context, *TeamMember) (*Team, error)
+	mustEmbedUnimplementedUserServiceServer()
+}
+
+// UnimplementedUserServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedUserServiceServer struct {
+}
+
+func (UnimplementedUserServiceServer) CreateUser(context.Context, *User) (*User, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateUser not implemented")
+}
+func (UnimplementedUserServiceServer) GetUser(context.Context, *UserID) (*User, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUser not implemented")
+}
+func (UnimplementedUserServiceServer) GetUsers(context.Context, *Empty) (*UserList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUsers not implemented")
+}
+func (UnimplementedUserServiceServer) UpdateUser(context.Context, *User) (*User, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateUser not implemented")
+}
+func (UnimplementedUserServiceServer) CreateTeam(context.Context, *Team) (*Team, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateTeam not implemented")
+}
+func (UnimplementedUserServiceServer) GetTeam(context.Context, *TeamID) (*Team, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTeam not implemented")
+}
+func (UnimplementedUserServiceServer) GetTeams(context.Context, *Empty) (*TeamList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTeams not implemented")
+}
+func (UnimplementedUserServiceServer) AddTeamMember(context.Context, *TeamMember) (*Team, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddTeamMember not implemented")
+}
+func (UnimplementedUserServiceServer) RemoveTeamMember(context.Context, *TeamMember) (*Team, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveTeamMember not implemented")
+}
+func (UnimplementedUserServiceServer) mustEmbedUnimplementedUserServiceServer() {}
+
+// UnsafeUserServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to UserServiceServer will
+// result in compilation errors.
+type UnsafeUserServiceServer interface {
+	mustEmbedUnimplementedUserServiceServer()
+}
+
+func RegisterUserServiceServer(s grpc.ServiceRegistrar, srv UserServiceServer) {
+	s.RegisterService(&UserService_ServiceDesc, srv)
+}
+
+func _UserService_CreateUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(User)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).CreateUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_CreateUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).CreateUser(ctx, req.(*User))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_GetUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).GetUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_GetUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).GetUser(ctx, req.(*UserID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_GetUsers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).GetUsers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_GetUsers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).GetUsers(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_UpdateUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(User)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).UpdateUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_UpdateUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).UpdateUser(ctx, req.(*User))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_CreateTeam_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Team)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).CreateTeam(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_CreateTeam_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).CreateTeam(ctx, req.(*Team))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_GetTeam_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TeamID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).GetTeam(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_GetTeam_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).GetTeam(ctx, req.(*TeamID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_GetTeams_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).GetTeams(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_GetTeams_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).GetTeams(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_AddTeamMember_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TeamMember)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).AddTeamMember(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_AddTeamMember_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).AddTeamMember(ctx, req.(*TeamMember))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_RemoveTeamMember_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TeamMember)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).RemoveTeamMember(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_RemoveTeamMember_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).RemoveTeamMember(ctx, req.(*TeamMember))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// UserService_ServiceDesc is the grpc.ServiceDesc for UserService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var UserService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "task.UserService",
+	HandlerType: (*UserServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateUser",
+			Handler:    _UserService_CreateUser_Handler,
+		},
+		{
+			MethodName: "GetUser",
+			Handler:    _UserService_GetUser_Handler,
+		},
+		{
+			MethodName: "GetUsers",
+			Handler:    _UserService_GetUsers_Handler,
+		},
+		{
+			MethodName: "UpdateUser",
+			Handler:    _UserService_UpdateUser_Handler,
+		},
+		{
+			MethodName: "CreateTeam",
+			Handler:    _UserService_CreateTeam_Handler,
+		},
+		{
+			MethodName: "GetTeam",
+			Handler:    _UserService_GetTeam_Handler,
+		},
+		{
+			MethodName: "GetTeams",
+			Handler:    _UserService_GetTeams_Handler,
+		},
+		{
+			MethodName: "AddTeamMember",
+			Handler:    _UserService_AddTeamMember_Handler,
+		},
+		{
+			MethodName: "RemoveTeamMember",
+			Handler:    _UserService_RemoveTeamMember_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "task.proto",
+}
+
+const (
+	AuthService_CreateAPIKey_FullMethodName       = "/task.AuthService/CreateAPIKey"
+	AuthService_GetAPIKeys_FullMethodName         = "/task.AuthService/GetAPIKeys"
+	AuthService_RevokeAPIKey_FullMethodName       = "/task.AuthService/RevokeAPIKey"
+	AuthService_AuthenticateAPIKey_FullMethodName = "/task.AuthService/AuthenticateAPIKey"
+	AuthService_ResolveUser_FullMethodName        = "/task.AuthService/ResolveUser"
+)
+
+// AuthServiceClient is the client API for AuthService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type AuthServiceClient interface {
+	CreateAPIKey(ctx context.Context, in *APIKey, opts ...grpc.CallOption) (*APIKey, error)
+	GetAPIKeys(ctx context.Context, in *APIKeyFilter, opts ...grpc.CallOption) (*APIKeyList, error)
+	RevokeAPIKey(ctx context.Context, in *APIKeyID, opts ...grpc.CallOption) (*APIKey, error)
+	// resolves a presented API key to its owner, failing with Unauthenticated if the key is unknown, expired or revoked
+	AuthenticateAPIKey(ctx context.Context, in *APIKeySecret, opts ...grpc.CallOption) (*Principal, error)
+	// resolves a user of the caller's workspace to their principal, for the bootstrap admin key acting on
+	// their behalf, failing with NotFound if the user doesn't exist
+	ResolveUser(ctx context.Context, in *UserID, opts ...grpc.CallOption) (*Principal, error)
+}
+
+type authServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAuthServiceClient(cc grpc.ClientConnInterface) AuthServiceClient {
+	return &authServiceClient{cc}
+}
+
+func (c *authServiceClient) CreateAPIKey(ctx context.Context, in *APIKey, opts ...grpc.CallOption) (*APIKey, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(APIKey)
+	err := c.cc.Invoke(ctx, AuthService_CreateAPIKey_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) GetAPIKeys(ctx context.Context, in *APIKeyFilter, opts ...grpc.CallOption) (*APIKeyList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(APIKeyList)
+	err := c.cc.Invoke(ctx, AuthService_GetAPIKeys_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) RevokeAPIKey(ctx context.Context, in *APIKeyID, opts ...grpc.CallOption) (*APIKey, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(APIKey)
+	err := c.cc.Invoke(ctx, AuthService_RevokeAPIKey_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) AuthenticateAPIKey(ctx context.Context, in *APIKeySecret, opts ...grpc.CallOption) (*Principal, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Principal)
+	err := c.cc.Invoke(ctx, AuthService_AuthenticateAPIKey_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) ResolveUser(ctx context.Context, in *UserID, opts ...grpc.CallOption) (*Principal, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Principal)
+	err := c.cc.Invoke(ctx, AuthService_ResolveUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AuthServiceServer is the server API for AuthService service.
+// All implementations must embed UnimplementedAuthServiceServer
+// for forward compatibility
+type AuthServiceServer interface {
+	CreateAPIKey(context.Context, *APIKey) (*APIKey, error)
+	GetAPIKeys(context.Context, *APIKeyFilter) (*APIKeyList, error)
+	RevokeAPIKey(context.Context, *APIKeyID) (*APIKey, error)
+	// resolves a presented API key to its owner, failing with Unauthenticated if the key is unknown, expired or revoked
+	AuthenticateAPIKey(context.Context, *APIKeySecret) (*Principal, error)
+	// resolves a user of the caller's workspace to their principal, for the bootstrap admin key acting on
+	// their behalf, failing with NotFound if the user doesn't exist
+	ResolveUser(context.Context, *UserID) (*Principal, error)
+	mustEmbedUnimplementedAuthServiceServer()
+}
+
+// UnimplementedAuthServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedAuthServiceServer struct {
+}
+
+func (UnimplementedAuthServiceServer) CreateAPIKey(context.Context, *APIKey) (*APIKey, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateAPIKey not implemented")
+}
+func (UnimplementedAuthServiceServer) GetAPIKeys(context.Context, *APIKeyFilter) (*APIKeyList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAPIKeys not implemented")
+}
+func (UnimplementedAuthServiceServer) RevokeAPIKey(context.Context, *APIKeyID) (*APIKey, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeAPIKey not implemented")
+}
+func (UnimplementedAuthServiceServer) AuthenticateAPIKey(context.Context, *APIKeySecret) (*Principal, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AuthenticateAPIKey not implemented")
+}
+func (UnimplementedAuthServiceServer) ResolveUser(context.Context, *UserID) (*Principal, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResolveUser not implemented")
+}
+func (UnimplementedAuthServiceServer) mustEmbedUnimplementedAuthServiceServer() {}
+
+// UnsafeAuthServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to AuthServiceServer will
+// result in compilation errors.
+type UnsafeAuthServiceServer interface {
+	mustEmbedUnimplementedAuthServiceServer()
+}
+
+func RegisterAuthServiceServer(s grpc.ServiceRegistrar, srv AuthServiceServer) {
+	s.RegisterService(&AuthService_ServiceDesc, srv)
+}
+
+func _AuthService_CreateAPIKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(APIKey)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).CreateAPIKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_CreateAPIKey_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).CreateAPIKey(ctx, req.(*APIKey))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_GetAPIKeys_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(APIKeyFilter)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).GetAPIKeys(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_GetAPIKeys_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).GetAPIKeys(ctx, req.(*APIKeyFilter))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_RevokeAPIKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(APIKeyID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).RevokeAPIKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_RevokeAPIKey_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).RevokeAPIKey(ctx, req.(*APIKeyID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_AuthenticateAPIKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(APIKeySecret)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).AuthenticateAPIKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_AuthenticateAPIKey_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).AuthenticateAPIKey(ctx, req.(*APIKeySecret))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_ResolveUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).ResolveUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_ResolveUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).ResolveUser(ctx, req.(*UserID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// AuthService_ServiceDesc is the grpc.ServiceDesc for AuthService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var AuthService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "task.AuthService",
+	HandlerType: (*AuthServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateAPIKey",
+			Handler:    _AuthService_CreateAPIKey_Handler,
+		},
+		{
+			MethodName: "GetAPIKeys",
+			Handler:    _AuthService_GetAPIKeys_Handler,
+		},
+		{
+			MethodName: "RevokeAPIKey",
+			Handler:    _AuthService_RevokeAPIKey_Handler,
+		},
+		{
+			MethodName: "AuthenticateAPIKey",
+			Handler:    _AuthService_AuthenticateAPIKey_Handler,
+		},
+		{
+			MethodName: "ResolveUser",
+			Handler:    _AuthService_ResolveUser_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "task.proto",
+}
+
+const (
+	ProjectService_CreateProject_FullMethodName = "/task.ProjectService/CreateProject"
+	ProjectService_GetProject_FullMethodName    = "/task.ProjectService/GetProject"
+	ProjectService_GetProjects_FullMethodName   = "/task.ProjectService/GetProjects"
+	ProjectService_UpdateProject_FullMethodName = "/task.ProjectService/UpdateProject"
+	ProjectService_DeleteProject_FullMethodName = "/task.ProjectService/DeleteProject"
+	ProjectService_CreateBoard_FullMethodName   = "/task.ProjectService/CreateBoard"
+	ProjectService_GetBoard_FullMethodName      = "/task.ProjectService/GetBoard"
+	ProjectService_GetBoards_FullMethodName     = "/task.ProjectService/GetBoards"
+	ProjectService_UpdateBoard_FullMethodName   = "/task.ProjectService/UpdateBoard"
+	ProjectService_DeleteBoard_FullMethodName   = "/task.ProjectService/DeleteBoard"
+	ProjectService_MoveCard_FullMethodName      = "/task.ProjectService/MoveCard"
+)
+
+// ProjectServiceClient is the client API for ProjectService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type ProjectServiceClient interface {
+	CreateProject(ctx context.Context, in *Project, opts ...grpc.CallOption) (*Project, error)
+	GetProject(ctx context.Context, in *ProjectID, opts ...grpc.CallOption) (*Project, error)
+	GetProjects(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ProjectList, error)
+	UpdateProject(ctx context.Context, in *Project, opts ...grpc.CallOption) (*Project, error)
+	// deletes a project and its boards, failing with FailedPrecondition while tasks still belong to it
+	DeleteProject(ctx context.Context, in *ProjectID, opts ...grpc.CallOption) (*Project, error)
+	CreateBoard(ctx context.Context, in *Board, opts ...grpc.CallOption) (*Board, error)
+	// returns the board's columns with the cards of the tasks the caller may see, ordered by rank
+	GetBoard(ctx context.Context, in *BoardID, opts ...grpc.CallOption) (*BoardView, error)
+	GetBoards(ctx context.Context, in *BoardFilter, opts ...grpc.CallOption) (*BoardList, error)
+	UpdateBoard(ctx context.Context, in *Board, opts ...grpc.CallOption) (*Board, error)
+	DeleteBoard(ctx context.Context, in *BoardID, opts ...grpc.CallOption) (*Board, error)
+	// moves a task's card to a column and position, setting its status and rank
+	MoveCard(ctx context.Context, in *MoveCardRequest, opts ...grpc.CallOption) (*MoveCardResponse, error)
+}
+
+type projectServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewProjectServiceClient(cc grpc.ClientConnInterface) ProjectServiceClient {
+	return &projectServiceClient{cc}
+}
+
+func (c *projectServiceClient) CreateProject(ctx context.Context, in *Project, opts ...grpc.CallOption) (*Project, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Project)
+	err := c.cc.Invoke(ctx, ProjectService_CreateProject_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *projectServiceClient) GetProject(ctx context.Context, in *ProjectID, opts ...grpc.CallOption) (*Project, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Project)
+	err := c.cc.Invoke(ctx, ProjectService_GetProject_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *projectServiceClient) GetProjects(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ProjectList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ProjectList)
+	err := c.cc.Invoke(ctx, ProjectService_GetProjects_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *projectServiceClient) UpdateProject(ctx context.Context, in *Project, opts ...grpc.CallOption) (*Project, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Project)
+	err := c.cc.Invoke(ctx, ProjectService_UpdateProject_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *projectServiceClient) DeleteProject(ctx context.Context, in *ProjectID, opts ...grpc.CallOption) (*Project, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Project)
+	err := c.cc.Invoke(ctx, ProjectService_DeleteProject_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *projectServiceClient) CreateBoard(ctx context.Context, in *Board, opts ...grpc.CallOption) (*Board, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Board)
+	err := c.cc.Invoke(ctx, ProjectService_CreateBoard_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *projectServiceClient) GetBoard(ctx context.Context, in *BoardID, opts ...grpc.CallOption) (*BoardView, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BoardView)
+	err := c.cc.Invoke(ctx, ProjectService_GetBoard_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *projectServiceClient) GetBoards(ctx context.Context, in *BoardFilter, opts ...grpc.CallOption) (*BoardList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BoardList)
+	err := c.cc.Invoke(ctx, ProjectService_GetBoards_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *projectServiceClient) UpdateBoard(ctx context.Context, in *Board, opts ...grpc.CallOption) (*Board, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Board)
+	err := c.cc.Invoke(ctx, ProjectService_UpdateBoard_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *projectServiceClient) DeleteBoard(ctx context.Context, in *BoardID, opts ...grpc.CallOption) (*Board, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Board)
+	err := c.cc.Invoke(ctx, ProjectService_DeleteBoard_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *projectServiceClient) MoveCard(ctx context.Context, in *MoveCardRequest, opts ...grpc.CallOption) (*MoveCardResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MoveCardResponse)
+	err := c.cc.Invoke(ctx, ProjectService_MoveCard_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ProjectServiceServer is the server API for ProjectService service.
+// All implementations must embed UnimplementedProjectServiceServer
+// for forward compatibility
+type ProjectServiceServer interface {
+	CreateProject(context.Context, *Project) (*Project, error)
+	GetProject(context.Context, *ProjectID) (*Project, error)
+	GetProjects(context.Context, *Empty) (*ProjectList, error)
+	UpdateProject(context.Context, *Project) (*Project, error)
+	// deletes a project and its boards, failing with FailedPrecondition while tasks still belong to it
+	DeleteProject(context.Context, *ProjectID) (*Project, error)
+	CreateBoard(context.Context, *Board) (*Board, error)
+	// returns the board's columns with the cards of the tasks the caller may see, ordered by rank
+	GetBoard(context.Context, *BoardID) (*BoardView, error)
+	GetBoards(context.Context, *BoardFilter) (*BoardList, error)
+	UpdateBoard(context.Context, *Board) (*Board, error)
+	DeleteBoard(context.Context, *BoardID) (*Board, error)
+	// moves a task's card to a column and position, setting its status and rank
+	MoveCard(context.Context, *MoveCardRequest) (*MoveCardResponse, error)
+	mustEmbedUnimplementedProjectServiceServer()
+}
+
+// UnimplementedProjectServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedProjectServiceServer struct {
+}
+
+func (UnimplementedProjectServiceServer) CreateProject(context.Context, *Project) (*Project, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateProject not implemented")
+}
+func (UnimplementedProjectServiceServer) GetProject(context.Context, *ProjectID) (*Project, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetProject not implemented")
+}
+func (UnimplementedProjectServiceServer) GetProjects(context.Context, *Empty) (*ProjectList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetProjects not implemented")
+}
+func (UnimplementedProjectServiceServer) UpdateProject(context.Context, *Project) (*Project, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateProject not implemented")
+}
+func (UnimplementedProjectServiceServer) DeleteProject(context.Context, *ProjectID) (*Project, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteProject not implemented")
+}
+func (UnimplementedProjectServiceServer) CreateBoard(context.Context, *Board) (*Board, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateBoard not implemented")
+}
+func (UnimplementedProjectServiceServer) GetBoard(context.Context, *BoardID) (*BoardView, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBoard not implemented")
+}
+func (UnimplementedProjectServiceServer) GetBoards(context.Context, *BoardFilter) (*BoardList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBoards not implemented")
+}
+func (UnimplementedProjectServiceServer) UpdateBoard(context.Context, *Board) (*Board, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateBoard not implemented")
+}
+func (UnimplementedProjectServiceServer) DeleteBoard(context.Context, *BoardID) (*Board, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteBoard not implemented")
+}
+func (UnimplementedProjectServiceServer) MoveCard(context.Context, *MoveCardRequest) (*MoveCardResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MoveCard not implemented")
+}
+func (UnimplementedProjectServiceServer) mustEmbedUnimplementedProjectServiceServer() {}
+
+// UnsafeProjectServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ProjectServiceServer will
+// result in compilation errors.
+type UnsafeProjectServiceServer interface {
+	mustEmbedUnimplementedProjectServiceServer()
+}
+
+func RegisterProjectServiceServer(s grpc.ServiceRegistrar, srv ProjectServiceServer) {
+	s.RegisterService(&ProjectService_ServiceDesc, srv)
+}
+
+func _ProjectService_CreateProject_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Project)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProjectServiceServer).CreateProject(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProjectService_CreateProject_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProjectServiceServer).CreateProject(ctx, req.(*Project))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProjectService_GetProject_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProjectID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProjectServiceServer).GetProject(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProjectService_GetProject_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProjectServiceServer).GetProject(ctx, req.(*ProjectID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProjectService_GetProjects_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProjectServiceServer).GetProjects(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProjectService_GetProjects_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProjectServiceServer).GetProjects(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProjectService_UpdateProject_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Project)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProjectServiceServer).UpdateProject(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProjectService_UpdateProject_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProjectServiceServer).UpdateProject(ctx, req.(*Project))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProjectService_DeleteProject_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProjectID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProjectServiceServer).DeleteProject(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProjectService_DeleteProject_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProjectServiceServer).DeleteProject(ctx, req.(*ProjectID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProjectService_CreateBoard_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Board)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProjectServiceServer).CreateBoard(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProjectService_CreateBoard_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProjectServiceServer).CreateBoard(ctx, req.(*Board))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProjectService_GetBoard_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BoardID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProjectServiceServer).GetBoard(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProjectService_GetBoard_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProjectServiceServer).GetBoard(ctx, req.(*BoardID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProjectService_GetBoards_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BoardFilter)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProjectServiceServer).GetBoards(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProjectService_GetBoards_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProjectServiceServer).GetBoards(ctx, req.(*BoardFilter))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProjectService_UpdateBoard_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Board)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProjectServiceServer).UpdateBoard(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProjectService_UpdateBoard_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProjectServiceServer).UpdateBoard(ctx, req.(*Board))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProjectService_DeleteBoard_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BoardID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProjectServiceServer).DeleteBoard(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProjectService_DeleteBoard_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProjectServiceServer).DeleteBoard(ctx, req.(*BoardID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProjectService_MoveCard_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MoveCardRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProjectServiceServer).MoveCard(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProjectService_MoveCard_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProjectServiceServer).MoveCard(ctx, req.(*MoveCardRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// ProjectService_ServiceDesc is the grpc.ServiceDesc for ProjectService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ProjectService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "task.ProjectService",
+	HandlerType: (*ProjectServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateProject",
+			Handler:    _ProjectService_CreateProject_Handler,
+		},
+		{
+			MethodName: "GetProject",
+			Handler:    _ProjectService_GetProject_Handler,
+		},
+		{
+			MethodName: "GetProjects",
+			Handler:    _ProjectService_GetProjects_Handler,
+		},
+		{
+			MethodName: "UpdateProject",
+			Handler:    _ProjectService_UpdateProject_Handler,
+		},
+		{
+			MethodName: "DeleteProject",
+			Handler:    _ProjectService_DeleteProject_Handler,
+		},
+		{
+			MethodName: "CreateBoard",
+			Handler:    _ProjectService_CreateBoard_Handler,
+		},
+		{
+			MethodName: "GetBoard",
+			Handler:    _ProjectService_GetBoard_Handler,
+		},
+		{
+			MethodName: "GetBoards",
+			Handler:    _ProjectService_GetBoards_Handler,
+		},
+		{
+			MethodName: "UpdateBoard",
+			Handler:    _ProjectService_UpdateBoard_Handler,
+		},
+		{
+			MethodName: "DeleteBoard",
+			Handler:    _ProjectService_DeleteBoard_Handler,
+		},
+		{
+			MethodName: "MoveCard",
+			Handler:    _ProjectService_MoveCard_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "task.proto",
+}
+
+const (
+	MilestoneService_CreateMilestone_FullMethodName = "/task.MilestoneService/CreateMilestone"
+	MilestoneService_GetMilestone_FullMethodName    = "/task.MilestoneService/GetMilestone"
+	MilestoneService_GetMilestones_FullMethodName   = "/task.MilestoneService/GetMilestones"
+	MilestoneService_UpdateMilestone_FullMethodName = "/task.MilestoneService/UpdateMilestone"
+	MilestoneService_DeleteMilestone_FullMethodName = "/task.MilestoneService/DeleteMilestone"
+	MilestoneService_GetBurndown_FullMethodName     = "/task.MilestoneService/GetBurndown"
+)
+
+// MilestoneServiceClient is the client API for MilestoneService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type MilestoneServiceClient interface {
+	CreateMilestone(ctx context.Context, in *Milestone, opts ...grpc.CallOption) (*Milestone, error)
+	GetMilestone(ctx context.Context, in *MilestoneID, opts ...grpc.CallOption) (*Milestone, error)
+	GetMilestones(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*MilestoneList, error)
+	UpdateMilestone(ctx context.Context, in *Milestone, opts ...grpc.CallOption) (*Milestone, error)
+	// fails with FailedPrecondition while tasks still belong to the milestone
+	DeleteMilestone(ctx context.Context, in *MilestoneID, opts ...grpc.CallOption) (*Milestone, error)
+	// replays the history of the milestone's tasks into the work remaining at the end of each day
+	GetBurndown(ctx context.Context, in *MilestoneID, opts ...grpc.CallOption) (*Burndown, error)
+}
+
+type milestoneServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewMilestoneServiceClient(cc grpc.ClientConnInterface) MilestoneServiceClient {
+	return &milestoneServiceClient{cc}
+}
+
+func (c *milestoneServiceClient) CreateMilestone(ctx context.Context, in *Milestone, opts ...grpc.CallOption) (*Milestone, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Milestone)
+	err := c.cc.Invoke(ctx, MilestoneService_CreateMilestone_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *milestoneServiceClient) GetMilestone(ctx context.Context, in *MilestoneID, opts ...grpc.CallOption) (*Milestone, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Milestone)
+	err := c.cc.Invoke(ctx, MilestoneService_GetMilestone_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *milestoneServiceClient) GetMilestones(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*MilestoneList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MilestoneList)
+	err := c.cc.Invoke(ctx, MilestoneService_GetMilestones_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *milestoneServiceClient) UpdateMilestone(ctx context.Context, in *Milestone, opts ...grpc.CallOption) (*Milestone, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Milestone)
+	err := c.cc.Invoke(ctx, MilestoneService_UpdateMilestone_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *milestoneServiceClient) DeleteMilestone(ctx context.Context, in *MilestoneID, opts ...grpc.CallOption) (*Milestone, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Milestone)
+	err := c.cc.Invoke(ctx, MilestoneService_DeleteMilestone_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *milestoneServiceClient) GetBurndown(ctx context.Context, in *MilestoneID, opts ...grpc.CallOption) (*Burndown, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Burndown)
+	err := c.cc.Invoke(ctx, MilestoneService_GetBurndown_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// MilestoneServiceServer is the server API for MilestoneService service.
+// All implementations must embed UnimplementedMilestoneServiceServer
+// for forward compatibility
+type MilestoneServiceServer interface {
+	CreateMilestone(context.Context, *Milestone) (*Milestone, error)
+	GetMilestone(context.Context, *MilestoneID) (*Milestone, error)
+	GetMilestones(context.Context, *Empty) (*MilestoneList, error)
+	UpdateMilestone(context.Context, *Milestone) (*Milestone, error)
+	// fails with FailedPrecondition while tasks still belong to the milestone
+	DeleteMilestone(context.Context, *MilestoneID) (*Milestone, error)
+	// replays the history of the milestone's tasks into the work remaining at the end of each day
+	GetBurndown(context.Context, *MilestoneID) (*Burndown, error)
+	mustEmbedUnimplementedMilestoneServiceServer()
+}
+
+// UnimplementedMilestoneServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedMilestoneServiceServer struct {
+}
+
+func (UnimplementedMilestoneServiceServer) CreateMilestone(context.Context, *Milestone) (*Milestone, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateMilestone not implemented")
+}
+func (UnimplementedMilestoneServiceServer) GetMilestone(context.Context, *MilestoneID) (*Milestone, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMilestone not implemented")
+}
+func (UnimplementedMilestoneServiceServer) GetMilestones(context.Context, *Empty) (*MilestoneList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMilestones not implemented")
+}
+func (UnimplementedMilestoneServiceServer) UpdateMilestone(context.Context, *Milestone) (*Milestone, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateMilestone not implemented")
+}
+func (UnimplementedMilestoneServiceServer) DeleteMilestone(context.Context, *MilestoneID) (*Milestone, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteMilestone not implemented")
+}
+func (UnimplementedMilestoneServiceServer) GetBurndown(context.Context, *MilestoneID) (*Burndown, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBurndown not implemented")
+}
+func (UnimplementedMilestoneServiceServer) mustEmbedUnimplementedMilestoneServiceServer() {}
+
+// UnsafeMilestoneServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to MilestoneServiceServer will
+// result in compilation errors.
+type UnsafeMilestoneServiceServer interface {
+	mustEmbedUnimplementedMilestoneServiceServer()
+}
+
+func RegisterMilestoneServiceServer(s grpc.ServiceRegistrar, srv MilestoneServiceServer) {
+	s.RegisterService(&MilestoneService_ServiceDesc, srv)
+}
+
+func _MilestoneService_CreateMilestone_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Milestone)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MilestoneServiceServer).CreateMilestone(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MilestoneService_CreateMilestone_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MilestoneServiceServer).CreateMilestone(ctx, req.(*Milestone))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MilestoneService_GetMilestone_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MilestoneID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MilestoneServiceServer).GetMilestone(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MilestoneService_GetMilestone_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MilestoneServiceServer).GetMilestone(ctx, req.(*MilestoneID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MilestoneService_GetMilestones_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MilestoneServiceServer).GetMilestones(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MilestoneService_GetMilestones_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MilestoneServiceServer).GetMilestones(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MilestoneService_UpdateMilestone_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Milestone)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MilestoneServiceServer).UpdateMilestone(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MilestoneService_UpdateMilestone_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MilestoneServiceServer).UpdateMilestone(ctx, req.(*Milestone))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MilestoneService_DeleteMilestone_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MilestoneID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MilestoneServiceServer).DeleteMilestone(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MilestoneService_DeleteMilestone_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MilestoneServiceServer).DeleteMilestone(ctx, req.(*MilestoneID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MilestoneService_GetBurndown_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MilestoneID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MilestoneServiceServer).GetBurndown(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MilestoneService_GetBurndown_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MilestoneServiceServer).GetBurndown(ctx, req.(*MilestoneID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// MilestoneService_ServiceDesc is the grpc.ServiceDesc for MilestoneService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var MilestoneService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "task.MilestoneService",
+	HandlerType: (*MilestoneServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateMilestone",
+			Handler:    _MilestoneService_CreateMilestone_Handler,
+		},
+		{
+			MethodName: "GetMilestone",
+			Handler:    _MilestoneService_GetMilestone_Handler,
+		},
+		{
+			MethodName: "GetMilestones",
+			Handler:    _MilestoneService_GetMilestones_Handler,
+		},
+		{
+			MethodName: "UpdateMilestone",
+			Handler:    _MilestoneService_UpdateMilestone_Handler,
+		},
+		{
+			MethodName: "DeleteMilestone",
+			Handler:    _MilestoneService_DeleteMilestone_Handler,
+		},
+		{
+			MethodName: "GetBurndown",
+			Handler:    _MilestoneService_GetBurndown_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "task.proto",
+}
+
+const (
+	WorkspaceService_CreateWorkspace_FullMethodName = "/task.WorkspaceService/CreateWorkspace"
+	WorkspaceService_GetWorkspace_FullMethodName    = "/task.WorkspaceService/GetWorkspace"
+	WorkspaceService_GetWorkspaces_FullMethodName   = "/task.WorkspaceService/GetWorkspaces"
+	WorkspaceService_UpdateWorkspace_FullMethodName = "/task.WorkspaceService/UpdateWorkspace"
+	WorkspaceService_DeleteWorkspace_FullMethodName = "/task.WorkspaceService/DeleteWorkspace"
+)
+
+// WorkspaceServiceClient is the client API for WorkspaceService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type WorkspaceServiceClient interface {
+	CreateWorkspace(ctx context.Context, in *Workspace, opts ...grpc.CallOption) (*Workspace, error)
+	GetWorkspace(ctx context.Context, in *WorkspaceID, opts ...grpc.CallOption) (*Workspace, error)
+	GetWorkspaces(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*WorkspaceList, error)
+	UpdateWorkspace(ctx context.Context, in *Workspace, opts ...grpc.CallOption) (*Workspace, error)
+	// deletes an empty workspace, failing with FailedPrecondition while it still holds users or tasks
+	DeleteWorkspace(ctx context.Context, in *WorkspaceID, opts ...grpc.CallOption) (*Workspace, error)
+}
+
+type workspaceServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewWorkspaceServiceClient(cc grpc.ClientConnInterface) WorkspaceServiceClient {
+	return &workspaceServiceClient{cc}
+}
+
+func (c *workspaceServiceClient) CreateWorkspace(ctx context.Context, in *Workspace, opts ...grpc.CallOption) (*Workspace, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Workspace)
+	err := c.cc.Invoke(ctx, WorkspaceService_CreateWorkspace_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *workspaceServiceClient) GetWorkspace(ctx context.Context, in *WorkspaceID, opts ...grpc.CallOption) (*Workspace, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Workspace)
+	err := c.cc.Invoke(ctx, WorkspaceService_GetWorkspace_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *workspaceServiceClient) GetWorkspaces(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*WorkspaceList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(WorkspaceList)
+	err := c.cc.Invoke(ctx, WorkspaceService_GetWorkspaces_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *workspaceServiceClient) UpdateWorkspace(ctx context.Context, in *Workspace, opts ...grpc.CallOption) (*Workspace, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Workspace)
+	err := c.cc.Invoke(ctx, WorkspaceService_UpdateWorkspace_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *workspaceServiceClient) DeleteWorkspace(ctx context.Context, in *WorkspaceID, opts ...grpc.CallOption) (*Workspace, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Workspace)
+	err := c.cc.Invoke(ctx, WorkspaceService_DeleteWorkspace_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// WorkspaceServiceServer is the server API for WorkspaceService service.
+// All implementations must embed UnimplementedWorkspaceServiceServer
+// for forward compatibility
+type WorkspaceServiceServer interface {
+	CreateWorkspace(context.Context, *Workspace) (*Workspace, error)
+	GetWorkspace(context.Context, *WorkspaceID) (*Workspace, error)
+	GetWorkspaces(context.Context, *Empty) (*WorkspaceList, error)
+	UpdateWorkspace(context.Context, *Workspace) (*Workspace, error)
+	// deletes an empty workspace, failing with FailedPrecondition while it still holds users or tasks
+	DeleteWorkspace(context.Context, *WorkspaceID) (*Workspace, error)
+	mustEmbedUnimplementedWorkspaceServiceServer()
+}
+
+// UnimplementedWorkspaceServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedWorkspaceServiceServer struct {
+}
+
+func (UnimplementedWorkspaceServiceServer) CreateWorkspace(context.Context, *Workspace) (*Workspace, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateWorkspace not implemented")
+}
+func (UnimplementedWorkspaceServiceServer) GetWorkspace(context.Context, *WorkspaceID) (*Workspace, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetWorkspace not implemented")
+}
+func (UnimplementedWorkspaceServiceServer) GetWorkspaces(context.Context, *Empty) (*WorkspaceList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetWorkspaces not implemented")
+}
+func (UnimplementedWorkspaceServiceServer) UpdateWorkspace(context.Context, *Workspace) (*Workspace, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateWorkspace not implemented")
+}
+func (UnimplementedWorkspaceServiceServer) DeleteWorkspace(context.Context, *WorkspaceID) (*Workspace, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteWorkspace not implemented")
+}
+func (UnimplementedWorkspaceServiceServer) mustEmbedUnimplementedWorkspaceServiceServer() {}
+
+// UnsafeWorkspaceServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to WorkspaceServiceServer will
+// result in compilation errors.
+type UnsafeWorkspaceServiceServer interface {
+	mustEmbedUnimplementedWorkspaceServiceServer()
+}
+
+func RegisterWorkspaceServiceServer(s grpc.ServiceRegistrar, srv WorkspaceServiceServer) {
+	s.RegisterService(&WorkspaceService_ServiceDesc, srv)
+}
+
+func _WorkspaceService_CreateWorkspace_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Workspace)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WorkspaceServiceServer).CreateWorkspace(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WorkspaceService_CreateWorkspace_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WorkspaceServiceServer).CreateWorkspace(ctx, req.(*Workspace))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WorkspaceService_GetWorkspace_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WorkspaceID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WorkspaceServiceServer).GetWorkspace(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WorkspaceService_GetWorkspace_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WorkspaceServiceServer).GetWorkspace(ctx, req.(*WorkspaceID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WorkspaceService_GetWorkspaces_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WorkspaceServiceServer).GetWorkspaces(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WorkspaceService_GetWorkspaces_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WorkspaceServiceServer).GetWorkspaces(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WorkspaceService_UpdateWorkspace_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Workspace)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WorkspaceServiceServer).UpdateWorkspace(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WorkspaceService_UpdateWorkspace_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WorkspaceServiceServer).UpdateWorkspace(ctx, req.(*Workspace))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WorkspaceService_DeleteWorkspace_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WorkspaceID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WorkspaceServiceServer).DeleteWorkspace(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WorkspaceService_DeleteWorkspace_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WorkspaceServiceServer).DeleteWorkspace(ctx, req.(*WorkspaceID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// WorkspaceService_ServiceDesc is the grpc.ServiceDesc for WorkspaceService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var WorkspaceService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "task.WorkspaceService",
+	HandlerType: (*WorkspaceServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateWorkspace",
+			Handler:    _WorkspaceService_CreateWorkspace_Handler,
+		},
+		{
+			MethodName: "GetWorkspace",
+			Handler:    _WorkspaceService_GetWorkspace_Handler,
+		},
+		{
+			MethodName: "GetWorkspaces",
+			Handler:    _WorkspaceService_GetWorkspaces_Handler,
+		},
+		{
+			MethodName: "UpdateWorkspace",
+			Handler:    _WorkspaceService_UpdateWorkspace_Handler,
+		},
+		{
+			MethodName: "DeleteWorkspace",
+			Handler:    _WorkspaceService_DeleteWorkspace_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "task.proto",
+}
+
+const (
+	AdminService_GetLogLevel_FullMethodName = "/task.AdminService/GetLogLevel"
+	AdminService_SetLogLevel_FullMethodName = "/task.AdminService/SetLogLevel"
+)
+
+// AdminServiceClient is the client API for AdminService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type AdminServiceClient interface {
+	GetLogLevel(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*LogLevel, error)
+	// changes the log level until the backend restarts
+	SetLogLevel(ctx context.Context, in *LogLevel, opts ...grpc.CallOption) (*LogLevel, error)
+}
+
+type adminServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAdminServiceClient(cc grpc.ClientConnInterface) AdminServiceClient {
+	return &adminServiceClient{cc}
+}
+
+func (c *adminServiceClient) GetLogLevel(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*LogLevel, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LogLevel)
+	err := c.cc.Invoke(ctx, AdminService_GetLogLevel_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) SetLogLevel(ctx context.Context, in *LogLevel, opts ...grpc.CallOption) (*LogLevel, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LogLevel)
+	err := c.cc.Invoke(ctx, AdminService_SetLogLevel_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AdminServiceServer is the server API for AdminService service.
+// All implementations must embed UnimplementedAdminServiceServer
+// for forward compatibility
+type AdminServiceServer interface {
+	GetLogLevel(context.Context, *Empty) (*LogLevel, error)
+	// changes the log level until the backend restarts
+	SetLogLevel(context.Context, *LogLevel) (*LogLevel, error)
+	mustEmbedUnimplementedAdminServiceServer()
+}
+
+// UnimplementedAdminServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedAdminServiceServer struct {
+}
+
+func (UnimplementedAdminServiceServer) GetLogLevel(context.Context, *Empty) (*LogLevel, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLogLevel not implemented")
+}
+func (UnimplementedAdminServiceServer) SetLogLevel(context.Context, *LogLevel) (*LogLevel, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetLogLevel not implemented")
+}
+func (UnimplementedAdminServiceServer) mustEmbedUnimplementedAdminServiceServer() {}
+
+// UnsafeAdminServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to AdminServiceServer will
+// result in compilation errors.
+type UnsafeAdminServiceServer interface {
+	mustEmbedUnimplementedAdminServiceServer()
+}
+
+func RegisterAdminServiceServer(s grpc.ServiceRegistrar, srv AdminServiceServer) {
+	s.RegisterService(&AdminService_ServiceDesc, srv)
+}
+
+func _AdminService_GetLogLevel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).GetLogLevel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_GetLogLevel_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).GetLogLevel(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_SetLogLevel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LogLevel)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).SetLogLevel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_SetLogLevel_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).SetLogLevel(ctx, req.(*LogLevel))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// AdminService_ServiceDesc is the grpc.ServiceDesc for AdminService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var AdminService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "task.AdminService",
+	HandlerType: (*AdminServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetLogLevel",
+			Handler:    _AdminService_GetLogLevel_Handler,
+		},
+		{
+			MethodName: "SetLogLevel",
+			Handler:    _AdminService_SetLogLevel_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "task.proto",
+}
+
+const (
+	RateLimitService_Take_FullMethodName = "/task.RateLimitService/Take"
+)
+
+// RateLimitServiceClient is the client API for RateLimitService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type RateLimitServiceClient interface {
+	// takes a token from the key's bucket, created full, if one is left
+	Take(ctx context.Context, in *RateLimitRequest, opts ...grpc.CallOption) (*RateLimitDecision, error)
+}
+
+type rateLimitServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewRateLimitServiceClient(cc grpc.ClientConnInterface) RateLimitServiceClient {
+	return &rateLimitServiceClient{cc}
+}
+
+func (c *rateLimitServiceClient) Take(ctx context.Context, in *RateLimitRequest, opts ...grpc.CallOption) (*RateLimitDecision, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RateLimitDecision)
+	err := c.cc.Invoke(ctx, RateLimitService_Take_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// RateLimitServiceServer is the server API for RateLimitService service.
+// All implementations must embed UnimplementedRateLimitServiceServer
+// for forward compatibility
+type RateLimitServiceServer interface {
+	// takes a token from the key's bucket, created full, if one is left
+	Take(context.Context, *RateLimitRequest) (*RateLimitDecision, error)
+	mustEmbedUnimplementedRateLimitServiceServer()
+}
+
+// UnimplementedRateLimitServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedRateLimitServiceServer struct {
+}
+
+func (UnimplementedRateLimitServiceServer) Take(context.Context, *RateLimitRequest) (*RateLimitDecision, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Take not implemented")
+}
+func (UnimplementedRateLimitServiceServer) mustEmbedUnimplementedRateLimitServiceServer() {}
+
+// UnsafeRateLimitServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to RateLimitServiceServer will
+// result in compilation errors.
+type UnsafeRateLimitServiceServer interface {
+	mustEmbedUnimplementedRateLimitServiceServer()
+}
+
+func RegisterRateLimitServiceServer(s grpc.ServiceRegistrar, srv RateLimitServiceServer) {
+	s.RegisterService(&RateLimitService_ServiceDesc, srv)
+}
+
+func _RateLimitService_Take_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RateLimitRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RateLimitServiceServer).Take(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RateLimitService_Take_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RateLimitServiceServer).Take(ctx, req.(*RateLimitRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// RateLimitService_ServiceDesc is the grpc.ServiceDesc for RateLimitService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var RateLimitService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "task.RateLimitService",
+	HandlerType: (*RateLimitServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Take",
+			Handler:    _RateLimitService_Take_Handler,
 		},
 	},
 	Streams:  []grpc.StreamDesc{},